@@ -0,0 +1,145 @@
+package builder
+
+import (
+	"github.com/ulule/loukoum/v3/stmt"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// MaxParameters is the maximum number of parameters of a PostgreSQL statement.
+const MaxParameters = 65535
+
+// Chunk is a query, and its arguments, generated for a part of a batch.
+type Chunk struct {
+	Query string
+	Args  []interface{}
+}
+
+// Batch splits given values in chunks, and builds a query for each of them using given function,
+// so that every query has at most limit parameters. It's used for large IN lists.
+// An error is returned if a query cannot stay under the limit, even with a single value.
+func Batch(values []interface{}, limit int, build func(values []interface{}) Builder) ([]Chunk, error) {
+	if limit <= 0 {
+		return nil, types.NewError(types.ErrInvalidLimit, "parameters limit must be a positive integer")
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	expressions := make([]stmt.Expression, len(values))
+	for i := range values {
+		expression, err := toExpression(values[i])
+		if err != nil {
+			return nil, err
+		}
+		expressions[i] = expression
+	}
+
+	// Parameters are counted with the dialect of the query, since it may bind values differently.
+	dialect := dialectOf(build(values[:1]))
+	counts := make([]int, len(values))
+	for i := range expressions {
+		counts[i] = countParameters(dialect, expressions[i])
+	}
+
+	return splitChunks(counts, limit, "value", func(start int, end int) Builder {
+		return build(values[start:end])
+	})
+}
+
+// splitChunks splits items, that bind given numbers of parameters, in chunks, and builds a query for each of
+// them using given function, so that every query has at most limit parameters.
+// Parameters of the query that are not bound to its items, such as other conditions, are shared by every chunk.
+func splitChunks(counts []int, limit int, item string, build func(start int, end int) Builder) ([]Chunk, error) {
+	_, args, err := build(0, 1).Build()
+	if err != nil {
+		return nil, err
+	}
+	shared := len(args) - counts[0]
+
+	chunks := []Chunk{}
+	start, total := 0, shared
+	for i := 0; i <= len(counts); i++ {
+		if i < len(counts) && shared+counts[i] > limit {
+			return nil, types.NewErrorf(types.ErrTooManyParameters,
+				"query has %d parameters for a single %s, which exceeds the limit of %d", shared+counts[i], item, limit)
+		}
+		if i < len(counts) && total+counts[i] <= limit {
+			total += counts[i]
+			continue
+		}
+
+		chunk, err := toChunk(build(start, i), limit)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+
+		if i < len(counts) {
+			start, total = i, shared+counts[i]
+		}
+	}
+
+	return chunks, nil
+}
+
+// toExpression returns given value as an expression, or an error if it has an unsupported type.
+func toExpression(value interface{}) (expression stmt.Expression, err error) {
+	defer func() {
+		if cause := recoverError(recover()); cause != nil {
+			err = cause
+		}
+	}()
+
+	return stmt.NewExpression(value), nil
+}
+
+// countParameters returns how many parameters are bound by given statement, such as a tuple or a function,
+// when it's written using given dialect.
+func countParameters(dialect types.Dialect, statement stmt.Statement) int {
+	ctx := types.AcquireStdContext(dialect)
+	defer ctx.Release()
+
+	statement.Write(ctx)
+	return len(ctx.Values())
+}
+
+// dialectOf returns the dialect of given builder, or nil if it uses the default one.
+func dialectOf(builder Builder) types.Dialect {
+	switch value := builder.(type) {
+	case Select:
+		return value.dialect
+	case *Select:
+		return value.dialect
+	case Insert:
+		return value.dialect
+	case *Insert:
+		return value.dialect
+	case Update:
+		return value.dialect
+	case *Update:
+		return value.dialect
+	case Delete:
+		return value.dialect
+	case *Delete:
+		return value.dialect
+	case Compound:
+		return value.dialect
+	case *Compound:
+		return value.dialect
+	default:
+		return nil
+	}
+}
+
+// toChunk builds given query, and checks that it has at most limit parameters.
+func toChunk(builder Builder, limit int) (Chunk, error) {
+	query, args, err := builder.Build()
+	if err != nil {
+		return Chunk{}, err
+	}
+	if len(args) > limit {
+		return Chunk{}, types.NewErrorf(types.ErrTooManyParameters,
+			"query has %d parameters, which exceeds the limit of %d", len(args), limit)
+	}
+	return Chunk{Query: query, Args: args}, nil
+}
@@ -0,0 +1,193 @@
+package builder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/builder"
+)
+
+func TestBatch(t *testing.T) {
+	is := require.New(t)
+
+	ids := []interface{}{1, 2, 3, 4, 5}
+	build := func(values []interface{}) builder.Builder {
+		return loukoum.
+			Select("id").
+			From("users").
+			Where(loukoum.Condition("id").In(values...)).
+			And(loukoum.Condition("status").Equal("active"))
+	}
+
+	// Values are split so that every query, including its shared parameters, stays under the limit...
+	{
+		chunks, err := loukoum.Batch(ids, 3, build)
+		is.NoError(err)
+		is.Equal([]builder.Chunk{
+			{
+				Query: "SELECT id FROM users WHERE ((id IN ($1, $2)) AND (status = $3))",
+				Args:  []interface{}{1, 2, "active"},
+			},
+			{
+				Query: "SELECT id FROM users WHERE ((id IN ($1, $2)) AND (status = $3))",
+				Args:  []interface{}{3, 4, "active"},
+			},
+			{
+				Query: "SELECT id FROM users WHERE ((id IN ($1)) AND (status = $2))",
+				Args:  []interface{}{5, "active"},
+			},
+		}, chunks)
+	}
+	{
+		chunks, err := loukoum.Batch(ids, loukoum.MaxParameters, build)
+		is.NoError(err)
+		is.Len(chunks, 1)
+		is.Equal([]interface{}{1, 2, 3, 4, 5, "active"}, chunks[0].Args)
+	}
+	{
+		chunks, err := loukoum.Batch(nil, 3, build)
+		is.NoError(err)
+		is.Empty(chunks)
+	}
+
+	{
+		// Values that bind several parameters are counted as such.
+		names := []interface{}{
+			loukoum.Func("CONCAT", "Thomas", "Vincent"),
+			"Yann",
+			loukoum.Func("CONCAT", "Maxime", "Pierre"),
+			loukoum.Func("CONCAT", "Florent", "Romain"),
+		}
+		chunks, err := loukoum.Batch(names, 4, func(values []interface{}) builder.Builder {
+			return loukoum.
+				Select("id").
+				From("users").
+				Where(loukoum.Condition("name").In(values...)).
+				And(loukoum.Condition("status").Equal("active"))
+		})
+		is.NoError(err)
+		is.Equal([]builder.Chunk{
+			{
+				Query: "SELECT id FROM users WHERE ((name IN (CONCAT($1, $2), $3)) AND (status = $4))",
+				Args:  []interface{}{"Thomas", "Vincent", "Yann", "active"},
+			},
+			{
+				Query: "SELECT id FROM users WHERE ((name IN (CONCAT($1, $2))) AND (status = $3))",
+				Args:  []interface{}{"Maxime", "Pierre", "active"},
+			},
+			{
+				Query: "SELECT id FROM users WHERE ((name IN (CONCAT($1, $2))) AND (status = $3))",
+				Args:  []interface{}{"Florent", "Romain", "active"},
+			},
+		}, chunks)
+
+		_, err = loukoum.Batch(names, 2, func(values []interface{}) builder.Builder {
+			return loukoum.Select("id").From("users").Where(loukoum.Condition("name").In(values...))
+		})
+		is.NoError(err)
+		_, err = loukoum.Batch(names, 1, func(values []interface{}) builder.Builder {
+			return loukoum.Select("id").From("users").Where(loukoum.Condition("name").In(values...))
+		})
+		is.True(errors.Is(err, loukoum.ErrTooManyParameters))
+	}
+
+	{
+		// Parameters are counted with the dialect of the query: MySQL binds a parameter every time it's used.
+		scores := []interface{}{
+			loukoum.Func("GREATEST", loukoum.Param("score"), loukoum.Param("score")),
+			loukoum.Func("LEAST", loukoum.Param("score"), loukoum.Param("score")),
+		}
+		chunks, err := loukoum.Batch(scores, 3, func(values []interface{}) builder.Builder {
+			return loukoum.
+				Select("id").
+				From("users").
+				Where(loukoum.Condition("score").In(values...)).
+				Dialect(loukoum.MySQL)
+		})
+		is.NoError(err)
+		is.Len(chunks, 2)
+		is.Equal("SELECT `id` FROM `users` WHERE (`score` IN (GREATEST(?, ?)))", chunks[0].Query)
+		is.Equal("SELECT `id` FROM `users` WHERE (`score` IN (LEAST(?, ?)))", chunks[1].Query)
+	}
+
+	// ...unless it's impossible.
+	{
+		_, err := loukoum.Batch(ids, 1, build)
+		is.True(errors.Is(err, loukoum.ErrTooManyParameters))
+	}
+	{
+		_, err := loukoum.Batch([]interface{}{struct{}{}}, 3, build)
+		is.True(errors.Is(err, loukoum.ErrInvalidExpression))
+	}
+	{
+		_, err := loukoum.Batch(ids, 0, build)
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+	}
+	{
+		_, err := loukoum.Batch(ids, 3, func(values []interface{}) builder.Builder {
+			return loukoum.Select("id").From("users").Limit(-1)
+		})
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+	}
+}
+
+func TestInsert_Batch(t *testing.T) {
+	is := require.New(t)
+
+	query := loukoum.
+		Insert("users").
+		Columns("email", "status", "created_at").
+		Values("a@ulule.com", "active", loukoum.Raw("NOW()")).
+		Values("b@ulule.com", "active", loukoum.Raw("NOW()")).
+		Values("c@ulule.com", loukoum.Default(), loukoum.Raw("NOW()")).
+		Values("d@ulule.com", "active", loukoum.Raw("NOW()")).
+		OnConflict("email", loukoum.DoUpdate(loukoum.Pair("status", "active")))
+
+	// Rows are split so that every query, including its shared parameters, stays under the limit...
+	{
+		chunks, err := query.Batch(5)
+		is.NoError(err)
+		is.Equal([]builder.Chunk{
+			{
+				Query: "INSERT INTO users (email, status, created_at) VALUES ($1, $2, NOW()), ($3, $4, NOW()) " +
+					"ON CONFLICT (email) DO UPDATE SET status = $5",
+				Args: []interface{}{"a@ulule.com", "active", "b@ulule.com", "active", "active"},
+			},
+			{
+				Query: "INSERT INTO users (email, status, created_at) VALUES ($1, DEFAULT, NOW()), ($2, $3, NOW()) " +
+					"ON CONFLICT (email) DO UPDATE SET status = $4",
+				Args: []interface{}{"c@ulule.com", "d@ulule.com", "active", "active"},
+			},
+		}, chunks)
+	}
+	{
+		chunks, err := query.Batch(loukoum.MaxParameters)
+		is.NoError(err)
+		is.Len(chunks, 1)
+		is.Len(chunks[0].Args, 8)
+	}
+	{
+		chunks, err := loukoum.Insert("users").Set(loukoum.Pair("email", "a@ulule.com")).Batch(1)
+		is.NoError(err)
+		is.Equal([]builder.Chunk{
+			{Query: "INSERT INTO users (email) VALUES ($1)", Args: []interface{}{"a@ulule.com"}},
+		}, chunks)
+	}
+
+	// ...unless it's impossible.
+	{
+		_, err := query.Batch(2)
+		is.True(errors.Is(err, loukoum.ErrTooManyParameters))
+	}
+	{
+		_, err := loukoum.Insert("users").Columns("email").Values("a@ulule.com", "b@ulule.com").Batch(10)
+		is.True(errors.Is(err, loukoum.ErrInvalidColumn))
+	}
+	{
+		_, err := query.Batch(-1)
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+	}
+}
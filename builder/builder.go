@@ -1,7 +1,6 @@
 package builder
 
 import (
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -16,37 +15,98 @@ type Builder interface {
 	// This function should be used for debugging since it doesn't escape anything and is completely
 	// vulnerable to SQL injection.
 	// You should use either NamedQuery() or Query()...
+	// If the query is invalid, its errors are returned instead.
 	String() string
 	// NamedQuery returns the underlying query as a named statement.
 	NamedQuery() (string, map[string]interface{})
 	// Query returns the underlying query as a regular statement.
 	Query() (string, []interface{})
+	// BuildNamed returns the underlying query as a named statement,
+	// or every error found while building and writing the query.
+	BuildNamed() (string, map[string]interface{}, error)
+	// Build returns the underlying query as a regular statement,
+	// or every error found while building and writing the query.
+	Build() (string, []interface{}, error)
+	// NamedQueryWith returns the underlying query as a named statement, using given naming style.
+	NamedQueryWith(style types.NamingStyle) (string, map[string]interface{})
+	// BuildNamedWith returns the underlying query as a named statement, using given naming style,
+	// or every error found while building and writing the query.
+	BuildNamedWith(style types.NamingStyle) (string, map[string]interface{}, error)
+	// QueryWith returns the underlying query as a regular statement, using given placeholder style.
+	QueryWith(style types.PlaceholderStyle) (string, []interface{})
+	// BuildWith returns the underlying query as a regular statement, using given placeholder style,
+	// or every error found while building and writing the query.
+	BuildWith(style types.PlaceholderStyle) (string, []interface{}, error)
 	// Statement returns underlying statement.
 	Statement() stmt.Statement
 }
 
 // IsSelectBuilder returns true if given builder is of type "Select"
 func IsSelectBuilder(builder Builder) bool {
-	_, ok := builder.(*Select)
-	return ok
+	switch builder.(type) {
+	case Select, *Select:
+		return true
+	default:
+		return false
+	}
 }
 
 // IsInsertBuilder returns true if given builder is of type "Insert"
 func IsInsertBuilder(builder Builder) bool {
-	_, ok := builder.(*Insert)
-	return ok
+	switch builder.(type) {
+	case Insert, *Insert:
+		return true
+	default:
+		return false
+	}
 }
 
 // IsUpdateBuilder returns true if given builder is of type "Update"
 func IsUpdateBuilder(builder Builder) bool {
-	_, ok := builder.(*Update)
-	return ok
+	switch builder.(type) {
+	case Update, *Update:
+		return true
+	default:
+		return false
+	}
 }
 
 // IsDeleteBuilder returns true if given builder is of type "Delete"
 func IsDeleteBuilder(builder Builder) bool {
-	_, ok := builder.(*Delete)
-	return ok
+	switch builder.(type) {
+	case Delete, *Delete:
+		return true
+	default:
+		return false
+	}
+}
+
+// recoverError returns given recovered value as an error if it was raised by loukoum.
+// Otherwise, the panic is propagated.
+func recoverError(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	err, ok := value.(*types.Error)
+	if !ok {
+		panic(value)
+	}
+	return err
+}
+
+// toError merges errors found while building and writing a query.
+func toError(errs types.Errors, err error) error {
+	if err == nil {
+		return errs.Err()
+	}
+	list, ok := err.(types.Errors)
+	if !ok {
+		return types.AppendError(errs, err)
+	}
+	for i := range list {
+		errs = types.AppendError(errs, list[i])
+	}
+	return errs.Err()
 }
 
 // ToColumn takes an empty interfaces and returns a Column instance.
@@ -59,11 +119,11 @@ func ToColumn(arg interface{}) stmt.Column {
 	case stmt.Column:
 		column = value
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as column", arg))
+		panic(types.NewErrorf(types.ErrInvalidColumn, "cannot use %T as column", arg))
 	}
 
 	if column.IsEmpty() {
-		panic("loukoum: given column is undefined")
+		panic(types.NewError(types.ErrInvalidColumn, "given column is undefined"))
 	}
 
 	return column
@@ -77,7 +137,7 @@ func ToColumns(values []interface{}) []stmt.Column { // nolint: gocyclo
 		case []stmt.Column:
 			for i := range array {
 				if array[i].IsEmpty() {
-					panic("loukoum: given column is undefined")
+					panic(types.NewError(types.ErrInvalidColumn, "given column is undefined"))
 				}
 			}
 			return array
@@ -99,23 +159,61 @@ func ToColumns(values []interface{}) []stmt.Column { // nolint: gocyclo
 			for y := range array {
 				column := stmt.NewColumn(strings.TrimSpace(array[y]))
 				if column.IsEmpty() {
-					panic("loukoum: given column is undefined")
+					panic(types.NewError(types.ErrInvalidColumn, "given column is undefined"))
 				}
 				columns = append(columns, column)
 			}
 		case stmt.Column:
 			if value.IsEmpty() {
-				panic("loukoum: given column is undefined")
+				panic(types.NewError(types.ErrInvalidColumn, "given column is undefined"))
 			}
 			columns = append(columns, value)
 		default:
-			panic(fmt.Sprintf("loukoum: cannot use %T as column", values[i]))
+			panic(types.NewErrorf(types.ErrInvalidColumn, "cannot use %T as column", values[i]))
 		}
 	}
 
 	return columns
 }
 
+// ToReturning takes a list of empty interfaces and returns a Returning clause.
+// Columns are given as string or Column instances, and computed values as SelectExpression instances: they're
+// kept in their given order.
+func ToReturning(values []interface{}) stmt.Returning {
+	if len(values) == 1 {
+		switch values[0].(type) {
+		case []string, []stmt.Column:
+			return stmt.NewReturningExpressions(toSelectExpressions(ToColumns(values)))
+		}
+	}
+
+	expressions := make([]stmt.SelectExpression, 0, len(values))
+
+	for i := range values {
+		switch value := values[i].(type) {
+		case string:
+			expressions = append(expressions, toSelectExpressions(ToColumns(values[i:i+1]))...)
+		case stmt.SelectExpression:
+			if value.IsEmpty() {
+				panic(types.NewError(types.ErrInvalidColumn, "given column is undefined"))
+			}
+			expressions = append(expressions, value)
+		default:
+			panic(types.NewErrorf(types.ErrInvalidColumn, "cannot use %T as column", values[i]))
+		}
+	}
+
+	return stmt.NewReturningExpressions(expressions)
+}
+
+func toSelectExpressions(columns []stmt.Column) []stmt.SelectExpression {
+	expressions := make([]stmt.SelectExpression, len(columns))
+	for i := range columns {
+		expressions[i] = columns[i]
+	}
+	return expressions
+}
+
 // ToSelectExpressions takes a list of empty interfaces and returns a slice of SelectExpression instance.
 func ToSelectExpressions(values []interface{}) []stmt.SelectExpression { // nolint: gocyclo
 	// If values is a slice, we try to use recursion to obtain a slice of Column.
@@ -127,7 +225,7 @@ func ToSelectExpressions(values []interface{}) []stmt.SelectExpression { // noli
 			expressions := make([]stmt.SelectExpression, 0, len(values))
 			for i := range array {
 				if array[i].IsEmpty() {
-					panic("loukoum: given column is undefined")
+					panic(types.NewError(types.ErrInvalidColumn, "given column is undefined"))
 				}
 				expressions = append(expressions, array[i])
 			}
@@ -147,26 +245,55 @@ func ToSelectExpressions(values []interface{}) []stmt.SelectExpression { // noli
 		switch value := values[i].(type) {
 		case stmt.SelectExpression:
 			if value.IsEmpty() {
-				panic("loukoum: given column is undefined")
+				panic(types.NewError(types.ErrInvalidColumn, "given column is undefined"))
 			}
 			columns = append(columns, value)
+		case StructColumns:
+			list := value.Columns()
+			for y := range list {
+				columns = append(columns, list[y])
+			}
 		case string:
 			array := strings.Split(value, ",")
 			for y := range array {
 				column := stmt.NewColumn(strings.TrimSpace(array[y]))
 				if column.IsEmpty() {
-					panic("loukoum: given column is undefined")
+					panic(types.NewError(types.ErrInvalidColumn, "given column is undefined"))
 				}
 				columns = append(columns, column)
 			}
 		default:
-			panic(fmt.Sprintf("loukoum: cannot use %T as column", values[i]))
+			panic(types.NewErrorf(types.ErrInvalidColumn, "cannot use %T as column", values[i]))
 		}
 	}
 
 	return columns
 }
 
+// ToDistinctOn takes a list of empty interfaces and returns a DistinctOn clause.
+// Expressions are given as column names, Column or Expression instances.
+func ToDistinctOn(values []interface{}) stmt.DistinctOn {
+	expressions := make([]stmt.Expression, 0, len(values))
+
+	for i := range values {
+		switch value := values[i].(type) {
+		case string:
+			expressions = append(expressions, stmt.NewIdentifier(value))
+		case stmt.Column:
+			expressions = append(expressions, stmt.NewIdentifier(value.Name))
+		case stmt.Expression:
+			expressions = append(expressions, value)
+		default:
+			panic(types.NewErrorf(types.ErrInvalidExpression, "cannot use %T as distinct on expression", values[i]))
+		}
+		if expressions[len(expressions)-1].IsEmpty() {
+			panic(types.NewError(types.ErrInvalidExpression, "given distinct on expression is undefined"))
+		}
+	}
+
+	return stmt.NewDistinctOn(expressions)
+}
+
 // ToTable takes an empty interfaces and returns a Table instance.
 func ToTable(arg interface{}) stmt.Table {
 	table := stmt.Table{}
@@ -177,11 +304,11 @@ func ToTable(arg interface{}) stmt.Table {
 	case stmt.Table:
 		table = value
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as table", arg))
+		panic(types.NewErrorf(types.ErrInvalidTable, "cannot use %T as table", arg))
 	}
 
 	if table.IsEmpty() {
-		panic("loukoum: given table is undefined")
+		panic(types.NewError(types.ErrInvalidTable, "given table is undefined"))
 	}
 
 	return table
@@ -210,11 +337,11 @@ func ToFrom(arg interface{}) stmt.From {
 	case stmt.Table:
 		from = stmt.NewFrom(value, false)
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as from clause", arg))
+		panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as from clause", arg))
 	}
 
 	if from.IsEmpty() {
-		panic("loukoum: given from clause is undefined")
+		panic(types.NewError(types.ErrInvalidClause, "given from clause is undefined"))
 	}
 
 	return from
@@ -232,11 +359,11 @@ func ToInto(arg interface{}) stmt.Into {
 	case stmt.Table:
 		into = stmt.NewInto(value)
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as into clause", arg))
+		panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as into clause", arg))
 	}
 
 	if into.IsEmpty() {
-		panic("loukoum: given into clause is undefined")
+		panic(types.NewError(types.ErrInvalidClause, "given into clause is undefined"))
 	}
 
 	return into
@@ -252,11 +379,11 @@ func ToSuffix(arg interface{}) stmt.Suffix {
 	case stmt.Suffix:
 		suffix = value
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as suffix", value))
+		panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as suffix", value))
 	}
 
 	if suffix.IsEmpty() {
-		panic("loukoum: given suffix is undefined")
+		panic(types.NewError(types.ErrInvalidClause, "given suffix is undefined"))
 	}
 
 	return suffix
@@ -272,11 +399,11 @@ func ToPrefix(arg interface{}) stmt.Prefix {
 	case stmt.Prefix:
 		prefix = value
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as prefix", value))
+		panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as prefix", value))
 	}
 
 	if prefix.IsEmpty() {
-		panic("loukoum: given prefix is undefined")
+		panic(types.NewError(types.ErrInvalidClause, "given prefix is undefined"))
 	}
 
 	return prefix
@@ -334,16 +461,92 @@ func MergeSet(set stmt.Set, args []interface{}) stmt.Set {
 		case types.Pair:
 			set.Pairs.Add(ToColumn(value.Key), stmt.NewWrapper(stmt.NewExpression(value.Value)))
 		default:
-			panic(fmt.Sprintf("loukoum: cannot use %T as pair", value))
+			if !isStruct(value) {
+				panic(types.NewErrorf(types.ErrInvalidPairs, "cannot use %T as pair", value))
+			}
+			for k, v := range ToMap(value) {
+				set.Pairs.Add(ToColumn(k), stmt.NewWrapper(stmt.NewExpression(v)))
+			}
 		}
 	}
 	return set
 }
 
-// ToSet takes either a types.Map or slice of types.Pair and returns a stmt.Set instance.
+// ToSet takes either a types.Map, a slice of types.Pair or a struct and returns a stmt.Set instance.
 func ToSet(args []interface{}) stmt.Set {
 	set := stmt.NewSet()
 	set.Pairs.Mode = stmt.PairAssociativeMode
 	set = MergeSet(set, args)
 	return set
 }
+
+// writeNamed writes given statement on a named context. If a parameter uses the name of an argument generated
+// before it, the statement is written again, so that generated names avoid the names of every parameter.
+func writeNamed(ctx *types.NamedContext, query stmt.Statement) {
+	query.Write(ctx)
+	if ctx.HasConflict() {
+		ctx.Rewind()
+		query.Write(ctx)
+	}
+}
+
+// toString writes given statement as a raw statement, using given dialect.
+// Errors found while building and writing the statement are returned in place of the query.
+func toString(dialect types.Dialect, query stmt.Statement, errs types.Errors) string {
+	ctx := types.AcquireRawContext(dialect)
+	defer ctx.Release()
+	query.Write(ctx)
+	err := toError(errs, ctx.Err())
+	if err != nil {
+		return err.Error()
+	}
+	return ctx.Query()
+}
+
+// build writes given statement as a regular statement, using given dialect and placeholder style,
+// or returns every error found while building and writing the statement.
+func build(dialect types.Dialect, query stmt.Statement, errs types.Errors,
+	style types.PlaceholderStyle) (string, []interface{}, error) {
+
+	ctx := types.AcquireStdContext(dialect)
+	defer ctx.Release()
+	ctx.SetPlaceholderStyle(style)
+	query.Write(ctx)
+	err := toError(errs, ctx.Err())
+	if err != nil {
+		return "", nil, err
+	}
+	return ctx.Query(), ctx.Values(), nil
+}
+
+// buildNamed writes given statement as a named statement, using given dialect and naming style,
+// or returns every error found while building and writing the statement.
+func buildNamed(dialect types.Dialect, query stmt.Statement, errs types.Errors,
+	style types.NamingStyle) (string, map[string]interface{}, error) {
+
+	ctx := types.AcquireNamedContext(dialect)
+	defer ctx.Release()
+	ctx.SetNamingStyle(style)
+	writeNamed(ctx, query)
+	err := toError(errs, ctx.Err())
+	if err != nil {
+		return "", nil, err
+	}
+	return ctx.Query(), ctx.Values(), nil
+}
+
+// mustBuild returns given regular statement, or panics with given error.
+func mustBuild(query string, args []interface{}, err error) (string, []interface{}) {
+	if err != nil {
+		panic(err)
+	}
+	return query, args
+}
+
+// mustBuildNamed returns given named statement, or panics with given error.
+func mustBuildNamed(query string, args map[string]interface{}, err error) (string, map[string]interface{}) {
+	if err != nil {
+		panic(err)
+	}
+	return query, args
+}
@@ -1,14 +1,19 @@
 package builder_test
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"testing"
 
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ulule/loukoum/v3"
 	"github.com/ulule/loukoum/v3/builder"
 	"github.com/ulule/loukoum/v3/stmt"
+	"github.com/ulule/loukoum/v3/types"
 )
 
 type BuilderTest struct {
@@ -49,10 +54,25 @@ func RunBuilderTests(t *testing.T, tests []BuilderTest) {
 		t.Run(tt.Name, func(t *testing.T) {
 			if tt.Failure != nil {
 				t.Run("Failure", func(t *testing.T) {
-					require.Panics(t, func() {
-						_ = tt.Failure().String()
+					_, _, err := tt.Failure().Build()
+					require.Error(t, err)
+					require.NotPanics(t, func() {
+						require.Equal(t, err.Error(), tt.Failure().String())
+						require.Equal(t, err.Error(), fmt.Sprintf("%v", tt.Failure()))
 					})
 				})
+				t.Run("Build", func(t *testing.T) {
+					query, args, err := tt.Failure().Build()
+					require.Error(t, err)
+					require.Empty(t, query)
+					require.Nil(t, args)
+				})
+				t.Run("BuildNamed", func(t *testing.T) {
+					query, args, err := tt.Failure().BuildNamed()
+					require.Error(t, err)
+					require.Empty(t, query)
+					require.Nil(t, args)
+				})
 				return
 			}
 			for i, builder := range tt.builders() {
@@ -75,6 +95,18 @@ func RunBuilderTests(t *testing.T, tests []BuilderTest) {
 						require.Equal(t, tt.NamedQuery, query)
 						require.Equal(t, toNamedArgs(tt.Args), args)
 					})
+					t.Run("Build", func(t *testing.T) {
+						query, args, err := builder.Build()
+						require.NoError(t, err)
+						require.Equal(t, tt.Query, query)
+						require.Equal(t, tt.Args, args)
+					})
+					t.Run("BuildNamed", func(t *testing.T) {
+						query, args, err := builder.BuildNamed()
+						require.NoError(t, err)
+						require.Equal(t, tt.NamedQuery, query)
+						require.Equal(t, toNamedArgs(tt.Args), args)
+					})
 				})
 			}
 		})
@@ -160,3 +192,457 @@ func TestToColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestIsBuilder(t *testing.T) {
+	is := require.New(t)
+
+	selectBuilder := loukoum.Select("id").From("users")
+	deleteBuilder := loukoum.Delete("users")
+
+	is.True(builder.IsSelectBuilder(selectBuilder))
+	is.True(builder.IsSelectBuilder(&selectBuilder))
+	is.False(builder.IsSelectBuilder(deleteBuilder))
+	is.True(builder.IsInsertBuilder(loukoum.Insert("users").Set(loukoum.Pair("id", 1))))
+	is.True(builder.IsUpdateBuilder(loukoum.Update("users").Set(loukoum.Pair("id", 1))))
+	is.True(builder.IsDeleteBuilder(deleteBuilder))
+	is.True(builder.IsDeleteBuilder(&deleteBuilder))
+	is.False(builder.IsDeleteBuilder(selectBuilder))
+}
+
+func TestBuild_Errors(t *testing.T) {
+	is := require.New(t)
+
+	// Errors are collected from builder methods...
+	{
+		query, args, err := loukoum.
+			Select("id").
+			From("users").
+			From("comments").
+			Limit(-1).
+			Offset("foo").
+			Build()
+
+		is.Error(err)
+		is.Empty(query)
+		is.Nil(args)
+		is.True(errors.Is(err, loukoum.ErrDuplicateClause))
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+		is.True(errors.Is(err, loukoum.ErrInvalidOffset))
+		is.Len(err.(types.Errors), 3)
+		is.Equal(fmt.Sprint(
+			"loukoum: select builder has from clause already defined; ",
+			"loukoum: limit must be a positive integer; ",
+			"loukoum: offset must be a non-negative integer",
+		), err.Error())
+	}
+
+	// ...from helpers...
+	{
+		_, _, err := loukoum.
+			Insert("users").
+			Columns("email", 42).
+			OnConflict("email").
+			BuildNamed()
+
+		is.True(errors.Is(err, loukoum.ErrInvalidColumn))
+		is.True(errors.Is(err, loukoum.ErrInvalidClause))
+		is.Len(err.(types.Errors), 2)
+	}
+
+	// ...and while writing the statement.
+	{
+		_, _, err := loukoum.
+			Insert("users").
+			Returning("id").
+			OnConflict("email", loukoum.DoNothing()).
+			Dialect(loukoum.MySQL).
+			Build()
+
+		is.True(errors.Is(err, loukoum.ErrUnsupportedFeature))
+		is.Len(err.(types.Errors), 2)
+	}
+	{
+		_, _, err := loukoum.Update(42).Set(loukoum.Pair("a", 1)).Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidTable))
+		is.True(errors.Is(err, loukoum.ErrEmptyStatement))
+	}
+	{
+		_, _, err := loukoum.
+			Select("id").
+			From("users").
+			OrderBy(loukoum.Order("id DESC, (SELECT 1)")).
+			Dialect(types.PostgreSQLDialect{Strict: true}).
+			Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidIdentifier))
+		is.Equal(`loukoum: "id DESC, (SELECT 1)" is not a valid identifier`, err.Error())
+	}
+
+	// Errors of embedded builders are reported by the outer builder...
+	{
+		invalid := loukoum.Select("id").From("users").Limit(-1)
+
+		_, _, err := loukoum.Select("*").
+			From("comments").
+			Where(loukoum.Condition("user_id").In(invalid)).
+			Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+
+		_, _, err = loukoum.Select("*").
+			From("comments").
+			Where(loukoum.Exists(invalid)).
+			Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+
+		_, _, err = loukoum.Select("*").
+			From("comments").
+			Where(loukoum.Condition("user_id").Equal(invalid)).
+			Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+
+		_, _, err = loukoum.Select(loukoum.Func("coalesce", invalid, 0)).
+			From("comments").
+			BuildNamed()
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+
+		_, _, err = loukoum.Select("*").
+			With(loukoum.With("archived", loukoum.Delete("comments").Where(nil).Returning("id"))).
+			From("archived").
+			Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidCondition))
+
+		_, _, err = loukoum.Select("*").
+			From("comments").
+			Union(loukoum.Select("*").From("archives").Where(loukoum.Condition("id").In(invalid))).
+			Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+		is.Len(err.(types.Errors), 1)
+	}
+
+	// ...and so are invalid conditions.
+	{
+		query := loukoum.Select("id").From("users").Where(nil)
+		is.True(errors.Is(query.Err(), loukoum.ErrInvalidCondition))
+
+		_, _, err := query.Where(loukoum.Condition("id").Equal(1)).Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidCondition))
+
+		_, _, err = loukoum.Update("users").Set(loukoum.Pair("a", 1)).Where(nil).Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidCondition))
+
+		_, _, err = loukoum.Delete("users").Where(loukoum.Condition("id").Equal(1)).Or(nil).Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidCondition))
+	}
+
+	// A failing builder method doesn't alter the builder.
+	{
+		valid := loukoum.Select("id").From("users")
+		invalid := valid.Limit(0)
+
+		query, _, err := valid.Build()
+		is.NoError(err)
+		is.Equal("SELECT id FROM users", query)
+		is.NoError(valid.Err())
+
+		query, _, err = invalid.Build()
+		is.Error(err)
+		is.Empty(query)
+		is.Error(invalid.Err())
+	}
+}
+
+func TestBuildWith(t *testing.T) {
+	is := require.New(t)
+
+	query := loukoum.Select("id").
+		From("users").
+		Where(loukoum.Condition("id").Equal(1)).
+		And(loukoum.Condition("status").In("active", "pending"))
+
+	// Placeholders use given style, regardless of the dialect...
+	{
+		expected := map[types.PlaceholderStyle]string{
+			loukoum.DollarPlaceholder:   "SELECT id FROM users WHERE ((id = $1) AND (status IN ($2, $3)))",
+			loukoum.QuestionPlaceholder: "SELECT id FROM users WHERE ((id = ?) AND (status IN (?, ?)))",
+			loukoum.AtPlaceholder:       "SELECT id FROM users WHERE ((id = @p1) AND (status IN (@p2, @p3)))",
+			loukoum.ColonPlaceholder:    "SELECT id FROM users WHERE ((id = :1) AND (status IN (:2, :3)))",
+		}
+		for style, sql := range expected {
+			query, args, err := query.BuildWith(style)
+			is.NoError(err)
+			is.Equal(sql, query)
+			is.Equal([]interface{}{1, "active", "pending"}, args)
+		}
+
+		sql, args := query.Dialect(loukoum.MySQL).QueryWith(loukoum.ColonPlaceholder)
+		is.Equal("SELECT `id` FROM `users` WHERE ((`id` = :1) AND (`status` IN (:2, :3)))", sql)
+		is.Equal([]interface{}{1, "active", "pending"}, args)
+	}
+
+	// ...whose numbered placeholders are reused by parameters...
+	{
+		sql, args := loukoum.Update("users").
+			Set(loukoum.Pair("updated_by", loukoum.Param("user_id"))).
+			Where(loukoum.Condition("id").Equal(loukoum.Param("user_id"))).
+			QueryWith(loukoum.AtPlaceholder)
+		is.Equal("UPDATE users SET updated_by = @p1 WHERE (id = @p1)", sql)
+		is.Equal([]interface{}{types.Param{Name: "user_id"}}, args)
+
+		sql, args = loukoum.Delete("users").
+			Where(loukoum.Condition("id").Equal(loukoum.Param("user_id"))).
+			Or(loukoum.Condition("parent_id").Equal(loukoum.Param("user_id"))).
+			QueryWith(loukoum.QuestionPlaceholder)
+		is.Equal("DELETE FROM users WHERE ((id = ?) OR (parent_id = ?))", sql)
+		is.Len(args, 2)
+	}
+
+	// ...and literal question marks are escaped for rebinders that unescape them.
+	{
+		sql, args := loukoum.Select("id").
+			From("users").
+			Where(loukoum.Raw("metadata ? 'admin'")).
+			And(loukoum.Condition("id").Equal(1)).
+			QueryWith(loukoum.RebindPlaceholder)
+		is.Equal("SELECT id FROM users WHERE (metadata ?? 'admin' AND (id = ?))", sql)
+		is.Equal([]interface{}{1}, args)
+
+		sql, _ = loukoum.Select("id").
+			From("users").
+			Where(loukoum.Raw("metadata ? 'admin'")).
+			QueryWith(loukoum.QuestionPlaceholder)
+		is.Equal("SELECT id FROM users WHERE metadata ? 'admin'", sql)
+
+		// Numbered formats of squirrel rebind placeholders and turn escaped question marks back into one...
+		query := loukoum.Select("id").
+			From("users").
+			Where(loukoum.Raw("metadata ? 'admin'")).
+			And(loukoum.Condition("id").Equal(1))
+		sql, _ = query.QueryWith(loukoum.RebindPlaceholder)
+		rebound, err := sq.Dollar.ReplacePlaceholders(sql)
+		is.NoError(err)
+		expected, _ := query.Query()
+		is.Equal("SELECT id FROM users WHERE (metadata ? 'admin' AND (id = $1))", expected)
+		is.Equal(expected, rebound)
+
+		// ...whereas sqlx doesn't, so question marks can't be escaped for it.
+		is.Equal("SELECT id FROM users WHERE (metadata $1$2 'admin' AND (id = $3))", sqlx.Rebind(sqlx.DOLLAR, sql))
+		sql, _ = query.QueryWith(loukoum.QuestionPlaceholder)
+		is.NotEqual(expected, sqlx.Rebind(sqlx.DOLLAR, sql))
+	}
+
+	// Errors are reported as Build() does.
+	{
+		sql, args, err := loukoum.Select("id").From("users").Offset(-1).BuildWith(loukoum.AtPlaceholder)
+		is.Error(err)
+		is.Empty(sql)
+		is.Nil(args)
+		is.Panics(func() {
+			loukoum.Select("id").From("users").Limit(0).QueryWith(loukoum.AtPlaceholder)
+		})
+	}
+}
+
+func TestBuildNamedWith(t *testing.T) {
+	is := require.New(t)
+
+	// Arguments are named after the column they're compared with...
+	{
+		query, args, err := loukoum.Select("id").
+			From("users").
+			Where(loukoum.Condition("users.email").Equal("tech@ulule.com")).
+			And(loukoum.Condition("status").In("active", "pending")).
+			And(loukoum.Condition("created_at").Between(1, 2)).
+			And(loukoum.Condition(`"Deleted"`).IsNull(true)).
+			BuildNamedWith(loukoum.ColumnNaming)
+		is.NoError(err)
+		is.Equal(fmt.Sprint(
+			"SELECT id FROM users WHERE ((((users.email = :email) AND (status IN (:status, :status_2))) ",
+			"AND (created_at BETWEEN :created_at AND :created_at_2)) AND (\"Deleted\" IS NULL))",
+		), query)
+		is.Equal(map[string]interface{}{
+			"email":        "tech@ulule.com",
+			"status":       "active",
+			"status_2":     "pending",
+			"created_at":   1,
+			"created_at_2": 2,
+		}, args)
+	}
+
+	// ...or assigned to...
+	{
+		query, args := loukoum.Update("users").
+			Set(loukoum.Pair("email", "tech@ulule.com"), loukoum.Pair("status", "active")).
+			Where(loukoum.Condition("email").Equal("contact@ulule.com")).
+			NamedQueryWith(loukoum.ColumnNaming)
+		is.Equal("UPDATE users SET email = :email, status = :status WHERE (email = :email_2)", query)
+		is.Equal(map[string]interface{}{
+			"email":   "tech@ulule.com",
+			"status":  "active",
+			"email_2": "contact@ulule.com",
+		}, args)
+
+		query, args = loukoum.Insert("users").
+			Columns("email", "status").
+			Values("tech@ulule.com", "active").
+			Values("contact@ulule.com", loukoum.Raw("DEFAULT")).
+			NamedQueryWith(loukoum.ColumnNaming)
+		is.Equal(
+			"INSERT INTO users (email, status) VALUES (:email, :status), (:email_2, DEFAULT)",
+			query,
+		)
+		is.Equal(map[string]interface{}{
+			"email":   "tech@ulule.com",
+			"status":  "active",
+			"email_2": "contact@ulule.com",
+		}, args)
+	}
+
+	// ...whereas other arguments are named after their position, as with IndexNaming.
+	{
+		query, args := loukoum.Select("id").
+			From("users").
+			Where(loukoum.Condition("id").Equal(loukoum.Param("user_id"))).
+			And(stmt.NewInfixExpression(
+				loukoum.Raw("LOWER(email)"),
+				stmt.NewComparisonOperator(types.Equal),
+				stmt.NewValue("tech@ulule.com"),
+			)).
+			NamedQueryWith(loukoum.ColumnNaming)
+		is.Equal("SELECT id FROM users WHERE ((id = :user_id) AND (LOWER(email) = :arg_1))", query)
+		is.Equal(map[string]interface{}{
+			"user_id": types.Param{Name: "user_id"},
+			"arg_1":   "tech@ulule.com",
+		}, args)
+
+		query, args, err := loukoum.Update("users").
+			Set(loukoum.Pair("email", "tech@ulule.com")).
+			Where(loukoum.Condition("id").Equal(loukoum.Param("email"))).
+			BuildNamedWith(loukoum.ColumnNaming)
+		is.NoError(err)
+		is.Equal("UPDATE users SET email = :email_2 WHERE (id = :email)", query)
+		is.Equal(map[string]interface{}{
+			"email":   types.Param{Name: "email"},
+			"email_2": "tech@ulule.com",
+		}, args)
+
+		update := loukoum.Update("users").
+			Set(loukoum.Pair("email", "tech@ulule.com")).
+			Where(loukoum.Condition("id").Equal(1))
+		query, args = update.NamedQueryWith(loukoum.IndexNaming)
+		expected, named := update.NamedQuery()
+		is.Equal("UPDATE users SET email = :arg_1 WHERE (id = :arg_2)", query)
+		is.Equal(expected, query)
+		is.Equal(named, args)
+	}
+}
+
+func TestBuild_Concurrent(t *testing.T) {
+	is := require.New(t)
+
+	// Contexts are pooled, so concurrent builds must not share their buffers or values.
+	query := loukoum.Select("id").From("users")
+	results := make(chan error, 20)
+	for i := 0; i < cap(results); i++ {
+		go func(id int) {
+			sql, args := query.Where(loukoum.Condition("id").Equal(id)).Query()
+			if sql != "SELECT id FROM users WHERE (id = $1)" || len(args) != 1 || args[0] != id {
+				results <- fmt.Errorf("unexpected query %s with %v for %d", sql, args, id)
+				return
+			}
+			results <- nil
+		}(i)
+	}
+	for i := 0; i < cap(results); i++ {
+		is.NoError(<-results)
+	}
+
+	// Neither must they reorder the pairs of a shared builder.
+	update := loukoum.Update("users").Set(loukoum.Pair("status", "active"), loukoum.Pair("email", "tech@ulule.com"))
+	for i := 0; i < cap(results); i++ {
+		go func(id int) {
+			sql, _ := update.Where(loukoum.Condition("id").Equal(id)).Query()
+			if sql != "UPDATE users SET email = $1, status = $2 WHERE (id = $3)" {
+				results <- fmt.Errorf("unexpected query %s for %d", sql, id)
+				return
+			}
+			results <- nil
+		}(i)
+	}
+	for i := 0; i < cap(results); i++ {
+		is.NoError(<-results)
+	}
+}
+
+// representativeBuilders returns builders used to measure allocations.
+func representativeBuilders() []struct {
+	Name    string
+	Builder builder.Builder
+} {
+	return []struct {
+		Name    string
+		Builder builder.Builder
+	}{
+		{
+			Name: "Select",
+			Builder: loukoum.Select("id", "email", "status", "created_at").
+				From("users").
+				Where(loukoum.Condition("id").Equal(1)).
+				And(loukoum.Condition("deleted_at").IsNull(true)).
+				And(loukoum.Condition("status").In("active", "pending")).
+				OrderBy(loukoum.Order("created_at", loukoum.Desc)).
+				Limit(10),
+		},
+		{
+			Name: "Insert",
+			Builder: loukoum.Insert("users").
+				Set(
+					loukoum.Pair("email", "tech@ulule.com"),
+					loukoum.Pair("status", "active"),
+					loukoum.Pair("created_at", loukoum.Raw("NOW()")),
+				).
+				Returning("id"),
+		},
+		{
+			Name: "Update",
+			Builder: loukoum.Update("users").
+				Set(
+					loukoum.Pair("email", "tech@ulule.com"),
+					loukoum.Pair("status", "active"),
+				).
+				Where(loukoum.Condition("id").Equal(1)),
+		},
+		{
+			Name: "Delete",
+			Builder: loukoum.Delete("users").
+				Where(loukoum.Condition("id").In(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)),
+		},
+	}
+}
+
+func BenchmarkBuilder_Query(b *testing.B) {
+	for _, tt := range representativeBuilders() {
+		tt := tt
+		b.Run(tt.Name, func(b *testing.B) {
+			b.Run("Query", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					_, _ = tt.Builder.Query()
+				}
+			})
+			// Writes the query on a new context every time, as queries were built before contexts were pooled.
+			b.Run("Unpooled", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					ctx := types.NewStdContext(types.PostgreSQL)
+					tt.Builder.Statement().Write(ctx)
+					_, _ = ctx.Query(), ctx.Values()
+				}
+			})
+			b.Run("NamedQuery", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					_, _ = tt.Builder.NamedQuery()
+				}
+			})
+		})
+	}
+}
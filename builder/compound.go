@@ -0,0 +1,215 @@
+package builder
+
+import (
+	"github.com/ulule/loukoum/v3/stmt"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// Compound is a builder used for "UNION", "INTERSECT" and "EXCEPT" queries.
+type Compound struct {
+	query   stmt.Compound
+	dialect types.Dialect
+	errs    types.Errors
+}
+
+// NewCompound creates a new Compound combining given queries, which are Select or Compound builders.
+func NewCompound(left interface{}, operator types.SetOperator, right interface{}) (next Compound) {
+	b := Compound{}
+	defer b.catch(&next)
+
+	b.query = stmt.NewCompound(b.operand(left), operator, b.operand(right))
+
+	return b
+}
+
+// Union combines the result of the query with given query, removing duplicate rows.
+func (b Compound) Union(query interface{}) Compound {
+	return b.combine(types.Union, query)
+}
+
+// UnionAll combines the result of the query with given query.
+func (b Compound) UnionAll(query interface{}) Compound {
+	return b.combine(types.UnionAll, query)
+}
+
+// Intersect returns rows that are in the result of both the query and given query.
+func (b Compound) Intersect(query interface{}) Compound {
+	return b.combine(types.Intersect, query)
+}
+
+// Except returns rows that are in the result of the query but not in the result of given query.
+func (b Compound) Except(query interface{}) Compound {
+	return b.combine(types.Except, query)
+}
+
+func (b Compound) combine(operator types.SetOperator, query interface{}) (next Compound) {
+	defer b.catch(&next)
+
+	b.query = stmt.NewCompound(b.query, operator, b.operand(query))
+
+	return b
+}
+
+// operand returns given query as a compound operand, and collects its errors.
+func (b *Compound) operand(query interface{}) stmt.Expression {
+	expression, dialect, errs := toQuery(query, "compound query")
+	b.inherit(dialect, errs)
+	return expression
+}
+
+// toQuery returns given query, which is a Select or Compound builder or statement, as an expression,
+// with the dialect and the errors of its builder.
+func toQuery(query interface{}, usage string) (stmt.Expression, types.Dialect, types.Errors) {
+	switch value := query.(type) {
+	case Select:
+		return value.query, value.dialect, value.errs
+	case Compound:
+		return value.query, value.dialect, value.errs
+	case stmt.Select:
+		return value, nil, nil
+	case stmt.Compound:
+		return value, nil, nil
+	default:
+		panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as %s", query, usage))
+	}
+}
+
+// inherit collects errors of an operand, and uses its dialect if none is defined.
+func (b *Compound) inherit(dialect types.Dialect, errs types.Errors) {
+	for i := range errs {
+		b.errs = types.AppendError(b.errs, errs[i])
+	}
+	if b.dialect == nil {
+		b.dialect = dialect
+	}
+}
+
+// OrderBy adds ORDER BY clauses, applied to the combined result.
+func (b Compound) OrderBy(orders ...stmt.Order) Compound {
+	b.query.OrderBy.Orders = append(b.query.OrderBy.Orders, orders...)
+	return b
+}
+
+// Limit adds LIMIT clause, applied to the combined result.
+func (b Compound) Limit(value interface{}) (next Compound) {
+	defer b.catch(&next)
+
+	if !b.query.Limit.IsEmpty() {
+		panic(types.NewError(types.ErrDuplicateClause, "compound builder has limit clause already defined"))
+	}
+
+	limit, ok := ToInt64(value)
+	if !ok || limit <= 0 {
+		panic(types.NewError(types.ErrInvalidLimit, "limit must be a positive integer"))
+	}
+
+	b.query.Limit = stmt.NewLimit(limit)
+
+	return b
+}
+
+// Offset adds OFFSET clause, applied to the combined result.
+func (b Compound) Offset(value interface{}) (next Compound) {
+	defer b.catch(&next)
+
+	if !b.query.Offset.IsEmpty() {
+		panic(types.NewError(types.ErrDuplicateClause, "compound builder has offset clause already defined"))
+	}
+
+	offset, ok := ToInt64(value)
+	if !ok || offset < 0 {
+		panic(types.NewError(types.ErrInvalidOffset, "offset must be a non-negative integer"))
+	}
+
+	b.query.Offset = stmt.NewOffset(offset)
+
+	return b
+}
+
+// Dialect defines the dialect used to generate the query.
+func (b Compound) Dialect(dialect types.Dialect) Compound {
+	b.dialect = dialect
+	return b
+}
+
+// String returns the underlying query as a raw statement.
+// This function should be used for debugging since it doesn't escape anything and is completely
+// vulnerable to SQL injection.
+// You should use either NamedQuery() or Query()...
+// If the query is invalid, its errors are returned instead.
+func (b Compound) String() string {
+	return toString(b.dialect, b.query, b.errs)
+}
+
+// NamedQuery returns the underlying query as a named statement.
+// It panics if the query is invalid: use BuildNamed() to handle errors.
+func (b Compound) NamedQuery() (string, map[string]interface{}) {
+	return mustBuildNamed(b.BuildNamed())
+}
+
+// Query returns the underlying query as a regular statement.
+// It panics if the query is invalid: use Build() to handle errors.
+func (b Compound) Query() (string, []interface{}) {
+	return mustBuild(b.Build())
+}
+
+// BuildNamed returns the underlying query as a named statement,
+// or every error found while building and writing the query.
+func (b Compound) BuildNamed() (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, types.IndexNaming)
+}
+
+// Build returns the underlying query as a regular statement,
+// or every error found while building and writing the query.
+func (b Compound) Build() (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, types.PlaceholderStyle{})
+}
+
+// NamedQueryWith returns the underlying query as a named statement, using given naming style for its arguments.
+// It panics if the query is invalid: use BuildNamedWith() to handle errors.
+func (b Compound) NamedQueryWith(style types.NamingStyle) (string, map[string]interface{}) {
+	return mustBuildNamed(b.BuildNamedWith(style))
+}
+
+// BuildNamedWith returns the underlying query as a named statement, using given naming style for its arguments,
+// or every error found while building and writing the query.
+func (b Compound) BuildNamedWith(style types.NamingStyle) (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, style)
+}
+
+// QueryWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect.
+// It panics if the query is invalid: use BuildWith() to handle errors.
+func (b Compound) QueryWith(style types.PlaceholderStyle) (string, []interface{}) {
+	return mustBuild(b.BuildWith(style))
+}
+
+// BuildWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect, or every error found while building and writing the query.
+func (b Compound) BuildWith(style types.PlaceholderStyle) (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, style)
+}
+
+// Statement returns underlying statement, which reports errors of the builder when it's written.
+func (b Compound) Statement() stmt.Statement {
+	query := b.query
+	query.Errors = b.errs
+	return query
+}
+
+// Err returns errors found while building the query, or nil if every clause is valid.
+func (b Compound) Err() error {
+	return b.errs.Err()
+}
+
+// catch records an error raised by a builder method and returns the builder as it was before the call.
+func (b Compound) catch(next *Compound) {
+	err := recoverError(recover())
+	if err != nil {
+		b.errs = types.AppendError(b.errs, err)
+		*next = b
+	}
+}
+
+// Ensure that Compound is a Builder
+var _ Builder = Compound{}
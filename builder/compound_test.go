@@ -0,0 +1,199 @@
+package builder_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/builder"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+func TestCompound_Operators(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Union",
+			Builder: loukoum.
+				Select("id").From("users").Where(loukoum.Condition("status").Equal("active")).
+				Union(loukoum.Select("id").From("admins").Where(loukoum.Condition("level").GreaterThan(2))),
+			String: fmt.Sprint(
+				"SELECT id FROM users WHERE (status = 'active') ",
+				"UNION SELECT id FROM admins WHERE (level > 2)",
+			),
+			Query: fmt.Sprint(
+				"SELECT id FROM users WHERE (status = $1) ",
+				"UNION SELECT id FROM admins WHERE (level > $2)",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT id FROM users WHERE (status = :arg_1) ",
+				"UNION SELECT id FROM admins WHERE (level > :arg_2)",
+			),
+			Args: []interface{}{"active", 2},
+		},
+		{
+			Name:      "Union all",
+			Builder:   loukoum.Select("id").From("a").UnionAll(loukoum.Select("id").From("b")),
+			SameQuery: "SELECT id FROM a UNION ALL SELECT id FROM b",
+		},
+		{
+			Name:      "Intersect",
+			Builder:   loukoum.Select("id").From("a").Intersect(loukoum.Select("id").From("b")),
+			SameQuery: "SELECT id FROM a INTERSECT SELECT id FROM b",
+		},
+		{
+			Name:      "Except",
+			Builder:   loukoum.Select("id").From("a").Except(loukoum.Select("id").From("b")),
+			SameQuery: "SELECT id FROM a EXCEPT SELECT id FROM b",
+		},
+		{
+			Name: "Chain",
+			Builder: loukoum.Select("id").From("a").
+				Union(loukoum.Select("id").From("b")).
+				Except(loukoum.Select("id").From("c")).
+				UnionAll(loukoum.Select("id").From("d")),
+			SameQuery: "SELECT id FROM a UNION SELECT id FROM b EXCEPT SELECT id FROM c UNION ALL SELECT id FROM d",
+		},
+	})
+}
+
+func TestCompound_Tail(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Order by, limit and offset",
+			Builder: loukoum.Select("id", "created_at").From("comments").Where(loukoum.Condition("user_id").Equal(1)).
+				UnionAll(loukoum.Select("id", "created_at").From("posts").Where(loukoum.Condition("user_id").Equal(1))).
+				OrderBy(loukoum.Order("created_at", loukoum.Desc)).
+				Limit(10).
+				Offset(20),
+			String: fmt.Sprint(
+				"SELECT id, created_at FROM comments WHERE (user_id = 1) ",
+				"UNION ALL SELECT id, created_at FROM posts WHERE (user_id = 1) ",
+				"ORDER BY created_at DESC LIMIT 10 OFFSET 20",
+			),
+			Query: fmt.Sprint(
+				"SELECT id, created_at FROM comments WHERE (user_id = $1) ",
+				"UNION ALL SELECT id, created_at FROM posts WHERE (user_id = $2) ",
+				"ORDER BY created_at DESC LIMIT 10 OFFSET 20",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT id, created_at FROM comments WHERE (user_id = :arg_1) ",
+				"UNION ALL SELECT id, created_at FROM posts WHERE (user_id = :arg_2) ",
+				"ORDER BY created_at DESC LIMIT 10 OFFSET 20",
+			),
+			Args: []interface{}{1, 1},
+		},
+		{
+			Name: "Operand with limit",
+			Builder: loukoum.Select("id").From("a").OrderBy(loukoum.Order("id")).Limit(1).
+				Union(loukoum.Select("id").From("b").Limit(2)),
+			SameQuery: "(SELECT id FROM a ORDER BY id ASC LIMIT 1) UNION (SELECT id FROM b LIMIT 2)",
+		},
+		{
+			Name: "Invalid limit",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("a").Union(loukoum.Select("id").From("b")).Limit(0)
+			},
+		},
+		{
+			Name: "Duplicate offset",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("a").Union(loukoum.Select("id").From("b")).Offset(1).Offset(2)
+			},
+		},
+	})
+}
+
+func TestCompound_Nested(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Right",
+			Builder: loukoum.Select("id").From("a").Where(loukoum.Condition("x").Equal(1)).
+				Union(loukoum.Select("id").From("b").Where(loukoum.Condition("x").Equal(2)).
+					Intersect(loukoum.Select("id").From("c").Where(loukoum.Condition("x").Equal(3)))),
+			String: fmt.Sprint(
+				"SELECT id FROM a WHERE (x = 1) UNION ",
+				"(SELECT id FROM b WHERE (x = 2) INTERSECT SELECT id FROM c WHERE (x = 3))",
+			),
+			Query: fmt.Sprint(
+				"SELECT id FROM a WHERE (x = $1) UNION ",
+				"(SELECT id FROM b WHERE (x = $2) INTERSECT SELECT id FROM c WHERE (x = $3))",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT id FROM a WHERE (x = :arg_1) UNION ",
+				"(SELECT id FROM b WHERE (x = :arg_2) INTERSECT SELECT id FROM c WHERE (x = :arg_3))",
+			),
+			Args: []interface{}{1, 2, 3},
+		},
+		{
+			Name: "Left precedence",
+			Builder: loukoum.Select("id").From("a").
+				Union(loukoum.Select("id").From("b")).
+				Intersect(loukoum.Select("id").From("c")),
+			SameQuery: "(SELECT id FROM a UNION SELECT id FROM b) INTERSECT SELECT id FROM c",
+		},
+		{
+			Name: "Left with limit",
+			Builder: loukoum.Select("id").From("a").
+				Union(loukoum.Select("id").From("b")).Limit(5).
+				Except(loukoum.Select("id").From("c")),
+			SameQuery: "(SELECT id FROM a UNION SELECT id FROM b LIMIT 5) EXCEPT SELECT id FROM c",
+		},
+		{
+			Name: "Subquery",
+			Builder: loukoum.Select("name").From("tags").
+				Where(loukoum.Condition("id").In(
+					loukoum.Select("tag_id").From("posts_tags").Union(loukoum.Select("tag_id").From("comments_tags")),
+				)),
+			SameQuery: fmt.Sprint(
+				"SELECT name FROM tags WHERE (id IN (SELECT tag_id FROM posts_tags UNION ",
+				"SELECT tag_id FROM comments_tags))",
+			),
+		},
+		{
+			Name: "Errors",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("a").Union(loukoum.Select("id").From("b").Limit(-1))
+			},
+		},
+		{
+			Name: "Invalid query",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("a").Union(loukoum.Insert("b"))
+			},
+		},
+	})
+}
+
+func TestCompound_SQLite(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Chain",
+			Builder: loukoum.Select("id").From("a").Where(loukoum.Condition("x").Equal(1)).
+				Union(loukoum.Select("id").From("b")).
+				Intersect(loukoum.Select("id").From("c")).
+				OrderBy(loukoum.Order("id")).
+				Dialect(loukoum.SQLite),
+			String: fmt.Sprint(
+				"SELECT id FROM a WHERE (x = 1) UNION SELECT id FROM b INTERSECT SELECT id FROM c ",
+				"ORDER BY id ASC",
+			),
+			Query: fmt.Sprint(
+				"SELECT id FROM a WHERE (x = ?1) UNION SELECT id FROM b INTERSECT SELECT id FROM c ",
+				"ORDER BY id ASC",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT id FROM a WHERE (x = :arg_1) UNION SELECT id FROM b INTERSECT SELECT id FROM c ",
+				"ORDER BY id ASC",
+			),
+			Args: []interface{}{1},
+		},
+		{
+			Name: "Nested",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("a").
+					Union(loukoum.Select("id").From("b").Intersect(loukoum.Select("id").From("c"))).
+					Dialect(types.SQLiteDialect{})
+			},
+		},
+	})
+}
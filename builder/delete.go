@@ -7,7 +7,9 @@ import (
 
 // Delete is a builder used for "SELECT" query.
 type Delete struct {
-	query stmt.Delete
+	query   stmt.Delete
+	dialect types.Dialect
+	errs    types.Errors
 }
 
 // NewDelete creates a new Delete.
@@ -16,9 +18,11 @@ func NewDelete() Delete {
 }
 
 // From sets the FROM clause of the query.
-func (b Delete) From(arg interface{}) Delete {
+func (b Delete) From(arg interface{}) (next Delete) {
+	defer b.catch(&next)
+
 	if !b.query.From.IsEmpty() {
-		panic("loukoum: delete builder has from clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "delete builder has from clause already defined"))
 	}
 
 	only := b.query.From.Only
@@ -37,9 +41,11 @@ func (b Delete) Only() Delete {
 }
 
 // Using adds a ONLY clause to the query.
-func (b Delete) Using(args ...interface{}) Delete {
+func (b Delete) Using(args ...interface{}) (next Delete) {
+	defer b.catch(&next)
+
 	if !b.query.Using.IsEmpty() {
-		panic("loukoum: delete builder has using clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "delete builder has using clause already defined"))
 	}
 
 	tables := ToTables(args)
@@ -48,8 +54,21 @@ func (b Delete) Using(args ...interface{}) Delete {
 	return b
 }
 
+// With adds WITH clauses.
+func (b Delete) With(args ...stmt.WithQuery) Delete {
+	if b.query.With.IsEmpty() {
+		b.query.With = stmt.NewWith(args)
+		return b
+	}
+
+	b.query.With.Queries = append(b.query.With.Queries, args...)
+	return b
+}
+
 // Where adds WHERE clauses.
-func (b Delete) Where(condition stmt.Expression) Delete {
+func (b Delete) Where(condition stmt.Expression) (next Delete) {
+	defer b.catch(&next)
+
 	if b.query.Where.IsEmpty() {
 		b.query.Where = stmt.NewWhere(condition)
 		return b
@@ -59,55 +78,117 @@ func (b Delete) Where(condition stmt.Expression) Delete {
 }
 
 // And adds AND WHERE conditions.
-func (b Delete) And(condition stmt.Expression) Delete {
+func (b Delete) And(condition stmt.Expression) (next Delete) {
+	defer b.catch(&next)
+
 	b.query.Where = b.query.Where.And(condition)
 	return b
 }
 
 // Or adds OR WHERE conditions.
-func (b Delete) Or(condition stmt.Expression) Delete {
+func (b Delete) Or(condition stmt.Expression) (next Delete) {
+	defer b.catch(&next)
+
 	b.query.Where = b.query.Where.Or(condition)
 	return b
 }
 
 // Returning adds a RETURNING clause.
-func (b Delete) Returning(values ...interface{}) Delete {
+func (b Delete) Returning(values ...interface{}) (next Delete) {
+	defer b.catch(&next)
+
 	if !b.query.Returning.IsEmpty() {
-		panic("loukoum: delete builder has returning clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "delete builder has returning clause already defined"))
 	}
 
-	b.query.Returning = stmt.NewReturning(ToColumns(values))
+	b.query.Returning = ToReturning(values)
 
 	return b
 }
 
+// Dialect defines the dialect used to generate the query.
+func (b Delete) Dialect(dialect types.Dialect) Delete {
+	b.dialect = dialect
+	return b
+}
+
 // String returns the underlying query as a raw statement.
 // This function should be used for debugging since it doesn't escape anything and is completely
 // vulnerable to SQL injection.
 // You should use either NamedQuery() or Query()...
+// If the query is invalid, its errors are returned instead.
 func (b Delete) String() string {
-	ctx := &types.RawContext{}
-	b.query.Write(ctx)
-	return ctx.Query()
+	return toString(b.dialect, b.query, b.errs)
 }
 
 // NamedQuery returns the underlying query as a named statement.
+// It panics if the query is invalid: use BuildNamed() to handle errors.
 func (b Delete) NamedQuery() (string, map[string]interface{}) {
-	ctx := &types.NamedContext{}
-	b.query.Write(ctx)
-	return ctx.Query(), ctx.Values()
+	return mustBuildNamed(b.BuildNamed())
 }
 
 // Query returns the underlying query as a regular statement.
+// It panics if the query is invalid: use Build() to handle errors.
 func (b Delete) Query() (string, []interface{}) {
-	ctx := &types.StdContext{}
-	b.query.Write(ctx)
-	return ctx.Query(), ctx.Values()
+	return mustBuild(b.Build())
+}
+
+// BuildNamed returns the underlying query as a named statement,
+// or every error found while building and writing the query.
+func (b Delete) BuildNamed() (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, types.IndexNaming)
+}
+
+// Build returns the underlying query as a regular statement,
+// or every error found while building and writing the query.
+func (b Delete) Build() (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, types.PlaceholderStyle{})
+}
+
+// NamedQueryWith returns the underlying query as a named statement, using given naming style for its arguments.
+// It panics if the query is invalid: use BuildNamedWith() to handle errors.
+func (b Delete) NamedQueryWith(style types.NamingStyle) (string, map[string]interface{}) {
+	return mustBuildNamed(b.BuildNamedWith(style))
+}
+
+// BuildNamedWith returns the underlying query as a named statement, using given naming style for its arguments,
+// or every error found while building and writing the query.
+func (b Delete) BuildNamedWith(style types.NamingStyle) (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, style)
+}
+
+// QueryWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect.
+// It panics if the query is invalid: use BuildWith() to handle errors.
+func (b Delete) QueryWith(style types.PlaceholderStyle) (string, []interface{}) {
+	return mustBuild(b.BuildWith(style))
+}
+
+// BuildWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect, or every error found while building and writing the query.
+func (b Delete) BuildWith(style types.PlaceholderStyle) (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, style)
 }
 
-// Statement returns underlying statement.
+// Statement returns underlying statement, which reports errors of the builder when it's written.
 func (b Delete) Statement() stmt.Statement {
-	return b.query
+	query := b.query
+	query.Errors = b.errs
+	return query
+}
+
+// Err returns errors found while building the query, or nil if every clause is valid.
+func (b Delete) Err() error {
+	return b.errs.Err()
+}
+
+// catch records an error raised by a builder method and returns the builder as it was before the call.
+func (b Delete) catch(next *Delete) {
+	err := recoverError(recover())
+	if err != nil {
+		b.errs = types.AppendError(b.errs, err)
+		*next = b
+	}
 }
 
 // Ensure that Delete is a Builder
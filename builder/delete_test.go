@@ -1,6 +1,7 @@
 package builder_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -111,3 +112,124 @@ func TestDelete_Returning(t *testing.T) {
 		},
 	})
 }
+
+func TestDelete_With(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Select",
+			Builder: loukoum.
+				Delete("comments").
+				With(loukoum.With("banned", loukoum.Select("id").From("users").Where(loukoum.Condition("banned").Equal(true)))).
+				Where(loukoum.Condition("user_id").In(loukoum.Select("id").From("banned"))),
+			String: fmt.Sprint(
+				"WITH banned AS (SELECT id FROM users WHERE (banned = true)) ",
+				"DELETE FROM comments WHERE (user_id IN (SELECT id FROM banned))",
+			),
+			Query: fmt.Sprint(
+				"WITH banned AS (SELECT id FROM users WHERE (banned = $1)) ",
+				"DELETE FROM comments WHERE (user_id IN (SELECT id FROM banned))",
+			),
+			NamedQuery: fmt.Sprint(
+				"WITH banned AS (SELECT id FROM users WHERE (banned = :arg_1)) ",
+				"DELETE FROM comments WHERE (user_id IN (SELECT id FROM banned))",
+			),
+			Args: []interface{}{true},
+		},
+		{
+			Name: "Update",
+			Builder: loukoum.
+				Delete("sessions").
+				With(loukoum.With("disabled", loukoum.
+					Update("users").
+					Set(loukoum.Pair("disabled", true)).
+					Where(loukoum.Condition("id").Equal(42)).
+					Returning("id"),
+				)).
+				Where(loukoum.Condition("user_id").In(loukoum.Select("id").From("disabled"))).
+				Returning("id"),
+			String: fmt.Sprint(
+				"WITH disabled AS (UPDATE users SET disabled = true WHERE (id = 42) RETURNING id) ",
+				"DELETE FROM sessions WHERE (user_id IN (SELECT id FROM disabled)) RETURNING id",
+			),
+			Query: fmt.Sprint(
+				"WITH disabled AS (UPDATE users SET disabled = $1 WHERE (id = $2) RETURNING id) ",
+				"DELETE FROM sessions WHERE (user_id IN (SELECT id FROM disabled)) RETURNING id",
+			),
+			NamedQuery: fmt.Sprint(
+				"WITH disabled AS (UPDATE users SET disabled = :arg_1 WHERE (id = :arg_2) RETURNING id) ",
+				"DELETE FROM sessions WHERE (user_id IN (SELECT id FROM disabled)) RETURNING id",
+			),
+			Args: []interface{}{true, 42},
+		},
+		{
+			Name: "MySQL",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Delete("sessions").
+					With(loukoum.With("deleted", loukoum.Delete("users").Where(loukoum.Condition("id").Equal(1)))).
+					Where(loukoum.Condition("user_id").In(loukoum.Select("id").From("deleted"))).
+					Dialect(loukoum.MySQL)
+			},
+		},
+	})
+}
+
+func TestDelete_MySQL(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name:       "Simple",
+			Builder:    loukoum.Delete("table").Where(loukoum.Condition("id").Equal(1)).Dialect(loukoum.MySQL),
+			String:     "DELETE FROM `table` WHERE (`id` = 1)",
+			Query:      "DELETE FROM `table` WHERE (`id` = ?)",
+			NamedQuery: "DELETE FROM `table` WHERE (`id` = :arg_1)",
+			Args:       []interface{}{1},
+		},
+		{
+			Name: "Only",
+			Failure: func() builder.Builder {
+				return loukoum.Delete("table").Only().Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "Using",
+			Failure: func() builder.Builder {
+				return loukoum.Delete("table").Using("test").Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "Returning",
+			Failure: func() builder.Builder {
+				return loukoum.Delete("table").Returning("id").Dialect(loukoum.MySQL)
+			},
+		},
+	})
+}
+
+func TestDelete_SQLite(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Returning",
+			Builder: loukoum.
+				Delete("table").
+				Where(loukoum.Condition("id").Equal(1)).
+				Returning("id").
+				Dialect(loukoum.SQLite),
+			String:     "DELETE FROM table WHERE (id = 1) RETURNING id",
+			Query:      "DELETE FROM table WHERE (id = ?1) RETURNING id",
+			NamedQuery: "DELETE FROM table WHERE (id = :arg_1) RETURNING id",
+			Args:       []interface{}{1},
+		},
+		{
+			Name: "Using",
+			Failure: func() builder.Builder {
+				return loukoum.Delete("table").Using("test").Dialect(loukoum.SQLite)
+			},
+		},
+		{
+			Name: "Only",
+			Failure: func() builder.Builder {
+				return loukoum.Delete("table").Only().Dialect(loukoum.SQLite)
+			},
+		},
+	})
+}
@@ -1,8 +1,12 @@
 // Package builder receives user input and generates an AST using "stmt" package.
 //
 // There is four builder to manipulate an AST: Select, Insert, Update and Delete.
+// Select queries can be combined with UNION, INTERSECT or EXCEPT using a Compound builder.
 //
 // When the AST is ready, you can use String(), NamedQuery() or Query() to generate the underlying query.
 // However, be vigilant with String(): it's mainly used for debugging because it's completely vulnerable
 // to SQL injection...
+//
+// These functions panic if the query is invalid. Build() and BuildNamed() return every error found while
+// building and writing the query instead.
 package builder
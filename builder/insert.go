@@ -1,15 +1,15 @@
 package builder
 
 import (
-	"fmt"
-
 	"github.com/ulule/loukoum/v3/stmt"
 	"github.com/ulule/loukoum/v3/types"
 )
 
 // Insert is a builder used for "INSERT" query.
 type Insert struct {
-	query stmt.Insert
+	query   stmt.Insert
+	dialect types.Dialect
+	errs    types.Errors
 }
 
 // NewInsert creates a new Insert.
@@ -20,9 +20,11 @@ func NewInsert() Insert {
 }
 
 // Into sets the INTO clause of the query.
-func (b Insert) Into(into interface{}) Insert {
+func (b Insert) Into(into interface{}) (next Insert) {
+	defer b.catch(&next)
+
 	if !b.query.Into.IsEmpty() {
-		panic("loukoum: insert builder has into clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "insert builder has into clause already defined"))
 	}
 
 	b.query.Into = ToInto(into)
@@ -31,12 +33,14 @@ func (b Insert) Into(into interface{}) Insert {
 }
 
 // Columns sets the query columns.
-func (b Insert) Columns(columns ...interface{}) Insert {
+func (b Insert) Columns(columns ...interface{}) (next Insert) {
+	defer b.catch(&next)
+
 	if len(columns) == 0 {
 		return b
 	}
 	if len(b.query.Columns) != 0 {
-		panic("loukoum: insert builder has columns clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "insert builder has columns clause already defined"))
 	}
 
 	b.query.Columns = ToColumns(columns)
@@ -44,36 +48,74 @@ func (b Insert) Columns(columns ...interface{}) Insert {
 	return b
 }
 
-// Values sets the query values.
-func (b Insert) Values(values ...interface{}) Insert {
-	if !b.query.Values.IsEmpty() {
-		panic("loukoum: insert builder has values clause already defined")
+// Values adds a row of values to the query.
+// It can be called several times to insert multiple rows, which must have the same number of values.
+func (b Insert) Values(values ...interface{}) (next Insert) {
+	defer b.catch(&next)
+
+	if b.query.Query != nil {
+		panic(types.NewError(types.ErrDuplicateClause, "insert builder has select query already defined"))
+	}
+	if len(values) == 0 {
+		return b
+	}
+
+	b.query.Values = b.query.Values.Append(stmt.NewArrayExpression(values...))
+
+	return b
+}
+
+// Select sets the query, which is a Select or Compound builder, whose rows are inserted.
+func (b Insert) Select(query interface{}) (next Insert) {
+	defer b.catch(&next)
+
+	if b.query.Values.Values != nil || b.query.Query != nil {
+		panic(types.NewError(types.ErrDuplicateClause, "insert builder has values clause already defined"))
+	}
+
+	expression, _, errs := toQuery(query, "insert query")
+	for i := range errs {
+		b.errs = types.AppendError(b.errs, errs[i])
 	}
+	b.query.Query = expression
+
+	return b
+}
 
-	b.query.Values = stmt.NewValues(stmt.NewArrayExpression(values...))
+// With adds WITH clauses.
+func (b Insert) With(args ...stmt.WithQuery) Insert {
+	if b.query.With.IsEmpty() {
+		b.query.With = stmt.NewWith(args)
+		return b
+	}
 
+	b.query.With.Queries = append(b.query.With.Queries, args...)
 	return b
 }
 
 // Returning builds the RETURNING clause.
-func (b Insert) Returning(values ...interface{}) Insert {
+func (b Insert) Returning(values ...interface{}) (next Insert) {
+	defer b.catch(&next)
+
 	if !b.query.Returning.IsEmpty() {
-		panic("loukoum: insert builder has returning clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "insert builder has returning clause already defined"))
 	}
 
-	b.query.Returning = stmt.NewReturning(ToColumns(values))
+	b.query.Returning = ToReturning(values)
 
 	return b
 }
 
 // OnConflict builds the ON CONFLICT clause.
-func (b Insert) OnConflict(args ...interface{}) Insert {
+func (b Insert) OnConflict(args ...interface{}) (next Insert) {
+	defer b.catch(&next)
+
 	if !b.query.OnConflict.IsEmpty() {
-		panic("loukoum: insert builder has on conflict clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "insert builder has on conflict clause already defined"))
 	}
 
 	if len(args) == 0 {
-		panic("loukoum: on conflict clause requires arguments")
+		panic(types.NewError(types.ErrInvalidClause, "on conflict clause requires arguments"))
 	}
 
 	for i := range args {
@@ -85,67 +127,170 @@ func (b Insert) OnConflict(args ...interface{}) Insert {
 			return b
 		case stmt.ConflictUpdateAction:
 			if b.query.OnConflict.Target.IsEmpty() {
-				panic("loukoum: on conflict update clause requires at least one target")
+				panic(types.NewError(types.ErrInvalidClause, "on conflict update clause requires at least one target"))
 			}
 			b.query.OnConflict.Action = value
 			return b
 		default:
-			panic(fmt.Sprintf("loukoum: cannot use %T as on conflict clause", args[i]))
+			panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as on conflict clause", args[i]))
 		}
 	}
 
-	panic("loukoum: on conflict clause requires an action")
+	panic(types.NewError(types.ErrInvalidClause, "on conflict clause requires an action"))
 }
 
 // Set is a wrapper that defines columns and values clauses using a pair.
-func (b Insert) Set(args ...interface{}) Insert {
-	if len(b.query.Columns) != 0 {
-		panic("loukoum: insert builder has columns clause already defined")
-	}
-	if !b.query.Values.IsEmpty() {
-		panic("loukoum: insert builder has values clause already defined")
+// It can be called several times to insert multiple rows, which must have the same columns.
+func (b Insert) Set(args ...interface{}) (next Insert) {
+	defer b.catch(&next)
+
+	if b.query.Query != nil {
+		panic(types.NewError(types.ErrDuplicateClause, "insert builder has select query already defined"))
 	}
 
 	pairs := ToSet(args).Pairs
 	columns, expressions := pairs.Values()
 
-	array := stmt.NewArrayExpression(expressions)
-	values := stmt.NewValues(array)
+	if len(b.query.Columns) == 0 {
+		b.query.Columns = columns
+	} else if !isSameColumns(b.query.Columns, columns) {
+		panic(types.NewError(types.ErrInvalidPairs, "insert builder rows must have the same columns"))
+	}
 
-	b.query.Columns = columns
-	b.query.Values = values
+	b.query.Values = b.query.Values.Append(stmt.NewArrayExpression(expressions))
 
 	return b
 }
 
+// Batch splits the rows of the query in chunks, so that every query has at most limit parameters.
+// An error is returned if a query cannot stay under the limit, even with a single row.
+func (b Insert) Batch(limit int) ([]Chunk, error) {
+	if limit <= 0 {
+		return nil, types.NewError(types.ErrInvalidLimit, "parameters limit must be a positive integer")
+	}
+
+	rows := b.query.Values.All()
+	if len(rows) <= 1 {
+		chunk, err := toChunk(b, limit)
+		if err != nil {
+			return nil, err
+		}
+		return []Chunk{chunk}, nil
+	}
+
+	counts := make([]int, len(rows))
+	for i := range rows {
+		counts[i] = countParameters(b.dialect, rows[i])
+	}
+
+	// Parameters that are not bound to rows, such as ON CONFLICT clause, are shared by every chunk.
+	return splitChunks(counts, limit, "row", func(start int, end int) Builder {
+		return b.rows(rows[start:end])
+	})
+}
+
+// rows returns the query using given rows as values.
+func (b Insert) rows(rows []stmt.Expression) Insert {
+	b.query.Values = stmt.NewValues(rows[0]).Append(rows[1:]...)
+	return b
+}
+
+// Dialect defines the dialect used to generate the query.
+func (b Insert) Dialect(dialect types.Dialect) Insert {
+	b.dialect = dialect
+	return b
+}
+
 // String returns the underlying query as a raw statement.
 // This function should be used for debugging since it doesn't escape anything and is completely
 // vulnerable to SQL injection.
 // You should use either NamedQuery() or Query()...
+// If the query is invalid, its errors are returned instead.
 func (b Insert) String() string {
-	ctx := &types.RawContext{}
-	b.query.Write(ctx)
-	return ctx.Query()
+	return toString(b.dialect, b.query, b.errs)
 }
 
 // NamedQuery returns the underlying query as a named statement.
+// It panics if the query is invalid: use BuildNamed() to handle errors.
 func (b Insert) NamedQuery() (string, map[string]interface{}) {
-	ctx := &types.NamedContext{}
-	b.query.Write(ctx)
-	return ctx.Query(), ctx.Values()
+	return mustBuildNamed(b.BuildNamed())
 }
 
 // Query returns the underlying query as a regular statement.
+// It panics if the query is invalid: use Build() to handle errors.
 func (b Insert) Query() (string, []interface{}) {
-	ctx := &types.StdContext{}
-	b.query.Write(ctx)
-	return ctx.Query(), ctx.Values()
+	return mustBuild(b.Build())
+}
+
+// BuildNamed returns the underlying query as a named statement,
+// or every error found while building and writing the query.
+func (b Insert) BuildNamed() (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, types.IndexNaming)
+}
+
+// Build returns the underlying query as a regular statement,
+// or every error found while building and writing the query.
+func (b Insert) Build() (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, types.PlaceholderStyle{})
+}
+
+// NamedQueryWith returns the underlying query as a named statement, using given naming style for its arguments.
+// It panics if the query is invalid: use BuildNamedWith() to handle errors.
+func (b Insert) NamedQueryWith(style types.NamingStyle) (string, map[string]interface{}) {
+	return mustBuildNamed(b.BuildNamedWith(style))
 }
 
-// Statement returns underlying statement.
+// BuildNamedWith returns the underlying query as a named statement, using given naming style for its arguments,
+// or every error found while building and writing the query.
+func (b Insert) BuildNamedWith(style types.NamingStyle) (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, style)
+}
+
+// QueryWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect.
+// It panics if the query is invalid: use BuildWith() to handle errors.
+func (b Insert) QueryWith(style types.PlaceholderStyle) (string, []interface{}) {
+	return mustBuild(b.BuildWith(style))
+}
+
+// BuildWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect, or every error found while building and writing the query.
+func (b Insert) BuildWith(style types.PlaceholderStyle) (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, style)
+}
+
+// Statement returns underlying statement, which reports errors of the builder when it's written.
 func (b Insert) Statement() stmt.Statement {
-	return b.query
+	query := b.query
+	query.Errors = b.errs
+	return query
+}
+
+// Err returns errors found while building the query, or nil if every clause is valid.
+func (b Insert) Err() error {
+	return b.errs.Err()
+}
+
+// catch records an error raised by a builder method and returns the builder as it was before the call.
+func (b Insert) catch(next *Insert) {
+	err := recoverError(recover())
+	if err != nil {
+		b.errs = types.AppendError(b.errs, err)
+		*next = b
+	}
 }
 
 // Ensure that Insert is a Builder
 var _ Builder = Insert{}
+
+func isSameColumns(left []stmt.Column, right []stmt.Column) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if left[i].Name != right[i].Name {
+			return false
+		}
+	}
+	return true
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ulule/loukoum/v3"
 	"github.com/ulule/loukoum/v3/builder"
+	"github.com/ulule/loukoum/v3/types"
 )
 
 func TestInsert_Columns(t *testing.T) {
@@ -412,6 +413,38 @@ func TestInsert_Returning(t *testing.T) {
 			),
 			Args: []interface{}{"va", "vb", "vc"},
 		},
+		{
+			Name: "Given order",
+			Builder: loukoum.
+				Insert("table").
+				Columns("b", "a").
+				Values([]string{"vb", "va"}).
+				Returning("id", loukoum.Func("lower", loukoum.Column("b")).As("lower_b"), "a"),
+			String: fmt.Sprint(
+				"INSERT INTO table (b, a) VALUES ('vb', 'va') ",
+				"RETURNING id, lower(b) AS lower_b, a",
+			),
+			Query: fmt.Sprint(
+				"INSERT INTO table (b, a) VALUES ($1, $2) ",
+				"RETURNING id, lower(b) AS lower_b, a",
+			),
+			NamedQuery: fmt.Sprint(
+				"INSERT INTO table (b, a) VALUES (:arg_1, :arg_2) ",
+				"RETURNING id, lower(b) AS lower_b, a",
+			),
+			Args: []interface{}{"vb", "va"},
+		},
+		{
+			Name: "Given order of columns",
+			Builder: loukoum.
+				Insert("table").
+				Set(loukoum.Pair("a", "va")).
+				Returning([]string{"updated_at", "id"}),
+			String:     "INSERT INTO table (a) VALUES ('va') RETURNING updated_at, id",
+			Query:      "INSERT INTO table (a) VALUES ($1) RETURNING updated_at, id",
+			NamedQuery: "INSERT INTO table (a) VALUES (:arg_1) RETURNING updated_at, id",
+			Args:       []interface{}{"va"},
+		},
 	})
 
 	// TODO: expression
@@ -533,3 +566,451 @@ func TestInsert_Set(t *testing.T) {
 		},
 	})
 }
+
+func TestInsert_Rows(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Values",
+			Builders: []builder.Builder{
+				loukoum.Insert("table").
+					Columns("email", "enabled").
+					Values("a@ulule.com", true).
+					Values("b@ulule.com", false),
+				loukoum.Insert("table").
+					Columns("email", "enabled").
+					Values([]interface{}{"a@ulule.com", true}).
+					Values([]interface{}{"b@ulule.com", false}),
+				loukoum.Insert("table").
+					Set(loukoum.Pair("email", "a@ulule.com"), loukoum.Pair("enabled", true)).
+					Set(loukoum.Map{"enabled": false, "email": "b@ulule.com"}),
+			},
+			String:     "INSERT INTO table (email, enabled) VALUES ('a@ulule.com', true), ('b@ulule.com', false)",
+			Query:      "INSERT INTO table (email, enabled) VALUES ($1, $2), ($3, $4)",
+			NamedQuery: "INSERT INTO table (email, enabled) VALUES (:arg_1, :arg_2), (:arg_3, :arg_4)",
+			Args:       []interface{}{"a@ulule.com", true, "b@ulule.com", false},
+		},
+		{
+			Name: "Default and raw values",
+			Builder: loukoum.
+				Insert("table").
+				Columns("email", "created_at").
+				Values("a@ulule.com", loukoum.Raw("NOW()")).
+				Values("b@ulule.com", loukoum.Default()),
+			String:     "INSERT INTO table (email, created_at) VALUES ('a@ulule.com', NOW()), ('b@ulule.com', DEFAULT)",
+			Query:      "INSERT INTO table (email, created_at) VALUES ($1, NOW()), ($2, DEFAULT)",
+			NamedQuery: "INSERT INTO table (email, created_at) VALUES (:arg_1, NOW()), (:arg_2, DEFAULT)",
+			Args:       []interface{}{"a@ulule.com", "b@ulule.com"},
+		},
+		{
+			Name: "On conflict and returning",
+			Builder: loukoum.
+				Insert("table").
+				Set(loukoum.Map{"email": "a@ulule.com", "enabled": true}).
+				Set(loukoum.Map{"email": "b@ulule.com", "enabled": true}).
+				OnConflict("email", loukoum.DoUpdate(loukoum.Pair("enabled", loukoum.Excluded("enabled")))).
+				Returning("id"),
+			String: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES ('a@ulule.com', true), ('b@ulule.com', true) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled RETURNING id",
+			),
+			Query: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES ($1, $2), ($3, $4) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled RETURNING id",
+			),
+			NamedQuery: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES (:arg_1, :arg_2), (:arg_3, :arg_4) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled RETURNING id",
+			),
+			Args: []interface{}{"a@ulule.com", true, "b@ulule.com", true},
+		},
+		{
+			Name: "Rows arity",
+			Failure: func() builder.Builder {
+				return loukoum.Insert("table").Values("a@ulule.com", true).Values("b@ulule.com")
+			},
+		},
+		{
+			Name: "Columns arity",
+			Failure: func() builder.Builder {
+				return loukoum.Insert("table").Columns("email", "enabled").Values("a@ulule.com")
+			},
+		},
+		{
+			Name: "Different columns",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("table").
+					Set(loukoum.Map{"email": "a@ulule.com", "enabled": true}).
+					Set(loukoum.Map{"email": "b@ulule.com", "status": "active"})
+			},
+		},
+		{
+			Name: "Select query already defined",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("table").
+					Columns("email").
+					Select(loukoum.Select("email").From("users")).
+					Values("a@ulule.com")
+			},
+		},
+	})
+}
+
+func TestInsert_Select(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Columns",
+			Builder: loukoum.
+				Insert("archive").
+				Columns("id", "message").
+				Select(loukoum.Select("id", "message").From("comments").Where(loukoum.Condition("status").Equal("old"))),
+			String:     "INSERT INTO archive (id, message) SELECT id, message FROM comments WHERE (status = 'old')",
+			Query:      "INSERT INTO archive (id, message) SELECT id, message FROM comments WHERE (status = $1)",
+			NamedQuery: "INSERT INTO archive (id, message) SELECT id, message FROM comments WHERE (status = :arg_1)",
+			Args:       []interface{}{"old"},
+		},
+		{
+			Name:      "Without columns",
+			Builder:   loukoum.Insert("archive").Select(loukoum.Select("*").From("comments")),
+			SameQuery: "INSERT INTO archive SELECT * FROM comments",
+		},
+		{
+			Name: "Wildcard",
+			Builder: loukoum.
+				Insert("archive").
+				Columns("id", "message").
+				Select(loukoum.Select("comments.*").From("comments")),
+			SameQuery: "INSERT INTO archive (id, message) SELECT comments.* FROM comments",
+		},
+		{
+			Name: "On conflict and returning",
+			Builder: loukoum.
+				Insert("members").
+				Columns("email", "team_id").
+				Select(loukoum.Select("email", "team_id").From("invitations").Where(loukoum.Condition("team_id").Equal(3))).
+				OnConflict("email", loukoum.DoUpdate(
+					loukoum.Pair("team_id", loukoum.Excluded("team_id")),
+					loukoum.Pair("status", "active"),
+				)).
+				Returning("id"),
+			String: fmt.Sprint(
+				"INSERT INTO members (email, team_id) SELECT email, team_id FROM invitations WHERE (team_id = 3) ",
+				"ON CONFLICT (email) DO UPDATE SET status = 'active', team_id = EXCLUDED.team_id RETURNING id",
+			),
+			Query: fmt.Sprint(
+				"INSERT INTO members (email, team_id) SELECT email, team_id FROM invitations WHERE (team_id = $1) ",
+				"ON CONFLICT (email) DO UPDATE SET status = $2, team_id = EXCLUDED.team_id RETURNING id",
+			),
+			NamedQuery: fmt.Sprint(
+				"INSERT INTO members (email, team_id) SELECT email, team_id FROM invitations WHERE (team_id = :arg_1) ",
+				"ON CONFLICT (email) DO UPDATE SET status = :arg_2, team_id = EXCLUDED.team_id RETURNING id",
+			),
+			Args: []interface{}{3, "active"},
+		},
+		{
+			Name: "Compound",
+			Builder: loukoum.
+				Insert("emails").
+				Columns("address").
+				Select(loukoum.Select("email").From("users").Union(loukoum.Select("email").From("invitations"))),
+			SameQuery: "INSERT INTO emails (address) SELECT email FROM users UNION SELECT email FROM invitations",
+		},
+		{
+			Name: "Columns mismatch",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("archive").
+					Columns("id", "message", "created_at").
+					Select(loukoum.Select("id", "message").From("comments"))
+			},
+		},
+		{
+			Name: "Values already defined",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("archive").
+					Columns("id").
+					Values(1).
+					Select(loukoum.Select("id").From("comments"))
+			},
+		},
+		{
+			Name: "Invalid query",
+			Failure: func() builder.Builder {
+				return loukoum.Insert("archive").Select("SELECT * FROM comments")
+			},
+		},
+		{
+			Name: "Invalid select builder",
+			Failure: func() builder.Builder {
+				return loukoum.Insert("archive").Select(loukoum.Select("id").From("comments").Limit(-1))
+			},
+		},
+	})
+}
+
+func TestInsert_MySQL(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Set",
+			Builder: loukoum.
+				Insert("table").
+				Set(
+					loukoum.Pair("email", "tech@ulule.com"),
+					loukoum.Pair("created_at", loukoum.Raw("NOW()")),
+				).
+				Dialect(loukoum.MySQL),
+			String:     "INSERT INTO `table` (`created_at`, `email`) VALUES (NOW(), 'tech@ulule.com')",
+			Query:      "INSERT INTO `table` (`created_at`, `email`) VALUES (NOW(), ?)",
+			NamedQuery: "INSERT INTO `table` (`created_at`, `email`) VALUES (NOW(), :arg_1)",
+			Args:       []interface{}{"tech@ulule.com"},
+		},
+		{
+			Name: "On duplicate key update",
+			Builder: loukoum.
+				Insert("table").
+				Columns("email", "enabled").
+				Values("tech@ulule.com", true).
+				OnConflict("email", loukoum.DoUpdate(
+					loukoum.Pair("enabled", true),
+					loukoum.Pair("updated_at", loukoum.Raw("NOW()")),
+				)).
+				Dialect(loukoum.MySQL),
+			String: fmt.Sprint(
+				"INSERT INTO `table` (`email`, `enabled`) VALUES ('tech@ulule.com', true) ",
+				"ON DUPLICATE KEY UPDATE `enabled` = true, `updated_at` = NOW()",
+			),
+			Query: fmt.Sprint(
+				"INSERT INTO `table` (`email`, `enabled`) VALUES (?, ?) ",
+				"ON DUPLICATE KEY UPDATE `enabled` = ?, `updated_at` = NOW()",
+			),
+			NamedQuery: fmt.Sprint(
+				"INSERT INTO `table` (`email`, `enabled`) VALUES (:arg_1, :arg_2) ",
+				"ON DUPLICATE KEY UPDATE `enabled` = :arg_3, `updated_at` = NOW()",
+			),
+			Args: []interface{}{"tech@ulule.com", true, true},
+		},
+		{
+			Name: "Do nothing",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("table").
+					Columns("email").
+					Values("tech@ulule.com").
+					OnConflict("email", loukoum.DoNothing()).
+					Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "Returning",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("table").
+					Columns("email").
+					Values("tech@ulule.com").
+					Returning("id").
+					Dialect(loukoum.MySQL)
+			},
+		},
+	})
+}
+
+func TestInsert_SQLite(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "On conflict do update",
+			Builder: loukoum.
+				Insert("table").
+				Columns("email", "enabled").
+				Values("tech@ulule.com", true).
+				OnConflict("email", loukoum.DoUpdate(
+					loukoum.Pair("enabled", loukoum.Excluded("enabled")),
+				)).
+				Returning("id").
+				Dialect(loukoum.SQLite),
+			String: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES ('tech@ulule.com', true) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled RETURNING id",
+			),
+			Query: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES (?1, ?2) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled RETURNING id",
+			),
+			NamedQuery: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES (:arg_1, :arg_2) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled RETURNING id",
+			),
+			Args: []interface{}{"tech@ulule.com", true},
+		},
+		{
+			Name: "Anonymous placeholders",
+			Builder: loukoum.
+				Insert("table").
+				Columns("email", "enabled").
+				Values("tech@ulule.com", true).
+				OnConflict("email", loukoum.DoNothing()).
+				Dialect(types.SQLiteDialect{Anonymous: true}),
+			String:     "INSERT INTO table (email, enabled) VALUES ('tech@ulule.com', true) ON CONFLICT (email) DO NOTHING",
+			Query:      "INSERT INTO table (email, enabled) VALUES (?, ?) ON CONFLICT (email) DO NOTHING",
+			NamedQuery: "INSERT INTO table (email, enabled) VALUES (:arg_1, :arg_2) ON CONFLICT (email) DO NOTHING",
+			Args:       []interface{}{"tech@ulule.com", true},
+		},
+		{
+			Name: "Returning on old version",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("table").
+					Columns("email").
+					Values("tech@ulule.com").
+					Returning("id").
+					Dialect(types.SQLiteDialect{Version: 3034001})
+			},
+		},
+		{
+			Name: "On conflict on old version",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("table").
+					Columns("email").
+					Values("tech@ulule.com").
+					OnConflict("email", loukoum.DoNothing()).
+					Dialect(types.SQLiteDialect{Version: 3023000})
+			},
+		},
+	})
+}
+
+func TestInsert_Excluded(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "PostgreSQL",
+			Builder: loukoum.
+				Insert("table").
+				Columns("email", "enabled").
+				Values("tech@ulule.com", true).
+				OnConflict("email", loukoum.DoUpdate(
+					loukoum.Pair("enabled", loukoum.Excluded("enabled")),
+				)),
+			String: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES ('tech@ulule.com', true) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled",
+			),
+			Query: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES ($1, $2) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled",
+			),
+			NamedQuery: fmt.Sprint(
+				"INSERT INTO table (email, enabled) VALUES (:arg_1, :arg_2) ",
+				"ON CONFLICT (email) DO UPDATE SET enabled = EXCLUDED.enabled",
+			),
+			Args: []interface{}{"tech@ulule.com", true},
+		},
+		{
+			Name: "MySQL",
+			Builder: loukoum.
+				Insert("table").
+				Columns("email", "enabled").
+				Values("tech@ulule.com", true).
+				OnConflict("email", loukoum.DoUpdate(
+					loukoum.Pair("enabled", loukoum.Excluded("enabled")),
+				)).
+				Dialect(loukoum.MySQL),
+			String: fmt.Sprint(
+				"INSERT INTO `table` (`email`, `enabled`) VALUES ('tech@ulule.com', true) ",
+				"ON DUPLICATE KEY UPDATE `enabled` = VALUES(`enabled`)",
+			),
+			Query: fmt.Sprint(
+				"INSERT INTO `table` (`email`, `enabled`) VALUES (?, ?) ",
+				"ON DUPLICATE KEY UPDATE `enabled` = VALUES(`enabled`)",
+			),
+			NamedQuery: fmt.Sprint(
+				"INSERT INTO `table` (`email`, `enabled`) VALUES (:arg_1, :arg_2) ",
+				"ON DUPLICATE KEY UPDATE `enabled` = VALUES(`enabled`)",
+			),
+			Args: []interface{}{"tech@ulule.com", true},
+		},
+	})
+}
+
+func TestInsert_Case(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "DoUpdate",
+			Builder: loukoum.
+				Insert("stocks").
+				Columns("sku", "quantity").
+				Values("A42", 3).
+				OnConflict("sku", loukoum.DoUpdate(
+					loukoum.Pair("quantity", loukoum.Case().
+						When(loukoum.Condition("stocks.locked").Equal(true), loukoum.Column("stocks.quantity")).
+						Else(loukoum.Excluded("quantity")),
+					),
+				)),
+			String: fmt.Sprint(
+				"INSERT INTO stocks (sku, quantity) VALUES ('A42', 3) ON CONFLICT (sku) DO UPDATE SET quantity = ",
+				"CASE WHEN (stocks.locked = true) THEN stocks.quantity ELSE EXCLUDED.quantity END",
+			),
+			Query: fmt.Sprint(
+				"INSERT INTO stocks (sku, quantity) VALUES ($1, $2) ON CONFLICT (sku) DO UPDATE SET quantity = ",
+				"CASE WHEN (stocks.locked = $3) THEN stocks.quantity ELSE EXCLUDED.quantity END",
+			),
+			NamedQuery: fmt.Sprint(
+				"INSERT INTO stocks (sku, quantity) VALUES (:arg_1, :arg_2) ON CONFLICT (sku) DO UPDATE SET quantity = ",
+				"CASE WHEN (stocks.locked = :arg_3) THEN stocks.quantity ELSE EXCLUDED.quantity END",
+			),
+			Args: []interface{}{"A42", 3, true},
+		},
+	})
+}
+
+func TestInsert_With(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Delete",
+			Builder: loukoum.
+				Insert("events").
+				With(loukoum.With("deleted", loukoum.
+					Delete("users").
+					Where(loukoum.Condition("id").Equal(42)).
+					Returning("id"),
+				)).
+				Set(
+					loukoum.Pair("kind", "user_deleted"),
+					loukoum.Pair("user_id", loukoum.Raw("(SELECT id FROM deleted)")),
+				),
+			String: fmt.Sprint(
+				"WITH deleted AS (DELETE FROM users WHERE (id = 42) RETURNING id) ",
+				"INSERT INTO events (kind, user_id) VALUES ('user_deleted', (SELECT id FROM deleted))",
+			),
+			Query: fmt.Sprint(
+				"WITH deleted AS (DELETE FROM users WHERE (id = $1) RETURNING id) ",
+				"INSERT INTO events (kind, user_id) VALUES ($2, (SELECT id FROM deleted))",
+			),
+			NamedQuery: fmt.Sprint(
+				"WITH deleted AS (DELETE FROM users WHERE (id = :arg_1) RETURNING id) ",
+				"INSERT INTO events (kind, user_id) VALUES (:arg_2, (SELECT id FROM deleted))",
+			),
+			Args: []interface{}{42, "user_deleted"},
+		},
+		{
+			Name: "SQLite",
+			Builder: loukoum.
+				Insert("events").
+				With(loukoum.With("last", loukoum.Select(loukoum.Max("id")).From("events"))).
+				Set(loukoum.Pair("parent_id", loukoum.Raw("(SELECT * FROM last)"))).
+				Dialect(loukoum.SQLite),
+			SameQuery: "WITH last AS (SELECT MAX(id) FROM events) INSERT INTO events (parent_id) VALUES ((SELECT * FROM last))",
+		},
+		{
+			Name: "MySQL",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Insert("events").
+					With(loukoum.With("last", loukoum.Select(loukoum.Max("id")).From("events"))).
+					Set(loukoum.Pair("parent_id", loukoum.Raw("(SELECT * FROM last)"))).
+					Dialect(loukoum.MySQL)
+			},
+		},
+	})
+}
@@ -0,0 +1,153 @@
+package builder
+
+import (
+	"sort"
+
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// Bind returns given positional arguments, as returned by Query() or Build(), with their named parameters
+// replaced by given values, which is a map or a struct using the "db" tag of its fields.
+// A parameter without value is reported, so is a key of a map that isn't a parameter of the query,
+// whereas fields of a struct that aren't parameters are ignored.
+func Bind(args []interface{}, values interface{}) ([]interface{}, error) {
+	b, err := newBinder(values)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []interface{}
+	if args != nil {
+		list = make([]interface{}, len(args))
+	}
+	for i := range args {
+		list[i] = b.bind(args[i])
+	}
+
+	err = b.err()
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// BindNamed returns given named arguments, as returned by NamedQuery() or BuildNamed(), with their named
+// parameters replaced by given values, which is a map or a struct using the "db" tag of its fields.
+// A parameter without value is reported, so is a key of a map that isn't a parameter of the query,
+// whereas fields of a struct that aren't parameters are ignored.
+func BindNamed(args map[string]interface{}, values interface{}) (map[string]interface{}, error) {
+	b, err := newBinder(values)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var list map[string]interface{}
+	if args != nil {
+		list = make(map[string]interface{}, len(args))
+	}
+	for _, name := range names {
+		list[name] = b.bind(args[name])
+	}
+
+	err = b.err()
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// binder replaces named parameters by their values, and collects missing and unknown parameters.
+type binder struct {
+	values map[string]interface{}
+	strict bool
+	used   map[string]bool
+	errs   types.Errors
+}
+
+func newBinder(values interface{}) (*binder, error) {
+	b := &binder{
+		values: make(map[string]interface{}),
+		strict: true,
+		used:   make(map[string]bool),
+	}
+
+	switch value := values.(type) {
+	case nil:
+	case map[string]interface{}:
+		b.values = value
+	case types.Map:
+		for k, v := range value {
+			name, ok := k.(string)
+			if !ok {
+				return nil, types.NewErrorf(types.ErrInvalidParameter, "cannot use %T as parameter name", k)
+			}
+			b.values[name] = v
+		}
+	default:
+		structValue, ok := toStructValue(values)
+		if !ok {
+			return nil, types.NewErrorf(types.ErrInvalidParameter, "cannot use %T as parameter values", values)
+		}
+
+		fields := getStructFields(structValue.Type())
+		for i := range fields {
+			if fields[i].Nested {
+				continue
+			}
+			field, ok := fieldByIndex(structValue, fields[i].Index)
+			if ok {
+				b.values[fields[i].Column] = toFieldValue(field)
+			}
+		}
+		b.strict = false
+	}
+
+	return b, nil
+}
+
+// bind returns the value of given argument if it's a named parameter, or the argument itself otherwise.
+func (b *binder) bind(arg interface{}) interface{} {
+	param, ok := arg.(types.Param)
+	if !ok {
+		return arg
+	}
+
+	value, ok := b.values[param.Name]
+	if !ok && !b.used[param.Name] {
+		b.errs = types.AppendError(b.errs, types.NewErrorf(types.ErrInvalidParameter,
+			"missing value for parameter %s", param.Name))
+	}
+	b.used[param.Name] = true
+
+	return value
+}
+
+// err returns every missing parameter, and every unknown parameter if values are given by a map.
+func (b *binder) err() error {
+	if !b.strict {
+		return b.errs.Err()
+	}
+
+	names := make([]string, 0, len(b.values))
+	for name := range b.values {
+		if !b.used[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for i := range names {
+		b.errs = types.AppendError(b.errs, types.NewErrorf(types.ErrInvalidParameter,
+			"unknown parameter %s", names[i]))
+	}
+
+	return b.errs.Err()
+}
@@ -0,0 +1,172 @@
+package builder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+func TestParam(t *testing.T) {
+	is := require.New(t)
+
+	query := loukoum.Select("id", "email").
+		From("users").
+		Where(loukoum.Condition("id").Equal(loukoum.Param("user_id"))).
+		And(loukoum.Condition("status").Equal("active")).
+		Or(loukoum.Condition("referrer_id").Equal(loukoum.Param("user_id")))
+
+	// Parameters reuse their placeholder if the dialect supports numbered placeholders...
+	{
+		sql, args, err := query.Build()
+		is.NoError(err)
+		is.Equal(
+			"SELECT id, email FROM users WHERE (((id = $1) AND (status = $2)) OR (referrer_id = $1))",
+			sql,
+		)
+		is.Equal([]interface{}{types.Param{Name: "user_id"}, "active"}, args)
+
+		sql, args, err = query.Dialect(loukoum.SQLite).Build()
+		is.NoError(err)
+		is.Equal(
+			"SELECT id, email FROM users WHERE (((id = ?1) AND (status = ?2)) OR (referrer_id = ?1))",
+			sql,
+		)
+		is.Equal([]interface{}{types.Param{Name: "user_id"}, "active"}, args)
+	}
+
+	// ...or use a new one otherwise...
+	{
+		sql, args, err := query.Dialect(loukoum.MySQL).Build()
+		is.NoError(err)
+		is.Equal(
+			"SELECT `id`, `email` FROM `users` WHERE (((`id` = ?) AND (`status` = ?)) OR (`referrer_id` = ?))",
+			sql,
+		)
+		is.Equal([]interface{}{
+			types.Param{Name: "user_id"},
+			"active",
+			types.Param{Name: "user_id"},
+		}, args)
+	}
+
+	// ...whereas they use their own name with named placeholders.
+	{
+		sql, args, err := query.BuildNamed()
+		is.NoError(err)
+		is.Equal(
+			"SELECT id, email FROM users WHERE (((id = :user_id) AND (status = :arg_1)) OR (referrer_id = :user_id))",
+			sql,
+		)
+		is.Equal(map[string]interface{}{
+			"user_id": types.Param{Name: "user_id"},
+			"arg_1":   "active",
+		}, args)
+
+		is.Equal(
+			"SELECT id, email FROM users WHERE (((id = :user_id) AND (status = 'active')) OR (referrer_id = :user_id))",
+			query.String(),
+		)
+	}
+
+	// Parameters names must be valid placeholders...
+	{
+		_, _, err := loukoum.Select("id").
+			From("users").
+			Where(loukoum.Condition("id").Equal(loukoum.Param("user-id"))).
+			Build()
+		is.True(errors.Is(err, loukoum.ErrInvalidParameter))
+	}
+
+	// ...and arguments names never conflict with them, whatever their order.
+	{
+		sql, args, err := loukoum.Select("id").
+			From("users").
+			Where(loukoum.Condition("id").Equal(1)).
+			And(loukoum.Condition("parent_id").Equal(loukoum.Param("arg_1"))).
+			And(loukoum.Condition("status").Equal("active")).
+			BuildNamed()
+		is.NoError(err)
+		is.Equal(
+			"SELECT id FROM users WHERE (((id = :arg_2) AND (parent_id = :arg_1)) AND (status = :arg_3))",
+			sql,
+		)
+		is.Equal(map[string]interface{}{
+			"arg_1": types.Param{Name: "arg_1"},
+			"arg_2": 1,
+			"arg_3": "active",
+		}, args)
+	}
+}
+
+func TestBind(t *testing.T) {
+	is := require.New(t)
+
+	query := loukoum.Update("users").
+		Set(
+			loukoum.Pair("email", loukoum.Param("email")),
+			loukoum.Pair("status", "active"),
+		).
+		Where(loukoum.Condition("id").Equal(loukoum.Param("id")))
+
+	// Positional arguments are bound from a map...
+	{
+		_, args := query.Dialect(loukoum.MySQL).Query()
+
+		values, err := loukoum.Bind(args, map[string]interface{}{"id": 1, "email": "tech@ulule.com"})
+		is.NoError(err)
+		is.Equal([]interface{}{"tech@ulule.com", "active", 1}, values)
+
+		values, err = loukoum.Bind(args, loukoum.Map{"id": 2, "email": "contact@ulule.com"})
+		is.NoError(err)
+		is.Equal([]interface{}{"contact@ulule.com", "active", 2}, values)
+
+		// Arguments are left untouched.
+		is.Equal(types.Param{Name: "email"}, args[0])
+	}
+
+	// ...or from a struct, whose unused fields are ignored...
+	{
+		_, args := query.Query()
+
+		values, err := loukoum.Bind(args, Comment{ID: 3, Email: "tech@ulule.com", Status: "pending"})
+		is.NoError(err)
+		is.Equal([]interface{}{"tech@ulule.com", "active", int64(3)}, values)
+	}
+
+	// ...and so are named arguments.
+	{
+		_, args := query.NamedQuery()
+
+		values, err := loukoum.BindNamed(args, map[string]interface{}{"id": 1, "email": "tech@ulule.com"})
+		is.NoError(err)
+		is.Equal(map[string]interface{}{
+			"email": "tech@ulule.com",
+			"arg_1": "active",
+			"id":    1,
+		}, values)
+	}
+
+	// Missing and unknown parameters are reported.
+	{
+		_, args := query.Query()
+
+		values, err := loukoum.Bind(args, map[string]interface{}{"email": "tech@ulule.com", "status": "active"})
+		is.Error(err)
+		is.Nil(values)
+		is.True(errors.Is(err, loukoum.ErrInvalidParameter))
+		is.Equal("loukoum: missing value for parameter id; loukoum: unknown parameter status", err.Error())
+
+		_, named := query.NamedQuery()
+
+		_, err = loukoum.BindNamed(named, nil)
+		is.Error(err)
+		is.Equal("loukoum: missing value for parameter email; loukoum: missing value for parameter id", err.Error())
+
+		_, err = loukoum.Bind(args, 42)
+		is.True(errors.Is(err, loukoum.ErrInvalidParameter))
+	}
+}
@@ -1,8 +1,6 @@
 package builder
 
 import (
-	"fmt"
-
 	"github.com/ulule/loukoum/v3/parser"
 	"github.com/ulule/loukoum/v3/stmt"
 	"github.com/ulule/loukoum/v3/types"
@@ -10,7 +8,9 @@ import (
 
 // Select is a builder used for "SELECT" query.
 type Select struct {
-	query stmt.Select
+	query   stmt.Select
+	dialect types.Dialect
+	errs    types.Errors
 }
 
 // NewSelect creates a new Select.
@@ -24,10 +24,29 @@ func (b Select) Distinct() Select {
 	return b
 }
 
+// DistinctOn adds a DISTINCT ON clause to the query, using given column names, Column or Expression instances.
+// The leading expressions of the ORDER BY clause must match these expressions.
+func (b Select) DistinctOn(args ...interface{}) (next Select) {
+	defer b.catch(&next)
+
+	if !b.query.DistinctOn.IsEmpty() {
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has distinct on clause already defined"))
+	}
+	if len(args) == 0 {
+		panic(types.NewError(types.ErrInvalidClause, "given distinct on clause is undefined"))
+	}
+
+	b.query.DistinctOn = ToDistinctOn(args)
+
+	return b
+}
+
 // Columns adds result columns to the query.
-func (b Select) Columns(args ...interface{}) Select {
+func (b Select) Columns(args ...interface{}) (next Select) {
+	defer b.catch(&next)
+
 	if len(b.query.Expressions) != 0 {
-		panic("loukoum: select builder has columns already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has columns already defined"))
 	}
 	if len(args) == 0 {
 		args = []interface{}{"*"}
@@ -39,9 +58,11 @@ func (b Select) Columns(args ...interface{}) Select {
 }
 
 // From sets the FROM clause of the query.
-func (b Select) From(arg interface{}) Select {
+func (b Select) From(arg interface{}) (next Select) {
+	defer b.catch(&next)
+
 	if !b.query.From.IsEmpty() {
-		panic("loukoum: select builder has from clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has from clause already defined"))
 	}
 
 	b.query.From = ToFrom(arg)
@@ -50,7 +71,9 @@ func (b Select) From(arg interface{}) Select {
 }
 
 // Join adds a JOIN clause to the query.
-func (b Select) Join(args ...interface{}) Select {
+func (b Select) Join(args ...interface{}) (next Select) {
+	defer b.catch(&next)
+
 	switch len(args) {
 	case 1:
 		return b.join1(args)
@@ -59,7 +82,7 @@ func (b Select) Join(args ...interface{}) Select {
 	case 3:
 		return b.join3(args)
 	default:
-		panic("loukoum: given join clause is invalid")
+		panic(types.NewError(types.ErrInvalidClause, "given join clause is invalid"))
 	}
 }
 
@@ -72,11 +95,11 @@ func (b Select) join1(args []interface{}) Select {
 	case stmt.Join:
 		join = value
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as join clause", args[0]))
+		panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as join clause", args[0]))
 	}
 
 	if join.IsEmpty() {
-		panic("loukoum: given join clause is undefined")
+		panic(types.NewError(types.ErrInvalidClause, "given join clause is undefined"))
 	}
 
 	b.query.Joins = append(b.query.Joins, join)
@@ -87,7 +110,7 @@ func (b Select) join1(args []interface{}) Select {
 func (b Select) join2(args []interface{}) Select {
 	join := handleSelectJoin(args)
 	if join.IsEmpty() {
-		panic("loukoum: given join clause is undefined")
+		panic(types.NewError(types.ErrInvalidClause, "given join clause is undefined"))
 	}
 
 	b.query.Joins = append(b.query.Joins, join)
@@ -102,11 +125,11 @@ func (b Select) join3(args []interface{}) Select {
 	case types.JoinType:
 		join.Type = value
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as join clause", args[1]))
+		panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as join clause", args[1]))
 	}
 
 	if join.IsEmpty() {
-		panic("loukoum: given join clause is undefined")
+		panic(types.NewError(types.ErrInvalidClause, "given join clause is undefined"))
 	}
 
 	b.query.Joins = append(b.query.Joins, join)
@@ -126,7 +149,9 @@ func (b Select) With(args ...stmt.WithQuery) Select {
 }
 
 // Where adds WHERE clauses.
-func (b Select) Where(condition stmt.Expression) Select {
+func (b Select) Where(condition stmt.Expression) (next Select) {
+	defer b.catch(&next)
+
 	if b.query.Where.IsEmpty() {
 		b.query.Where = stmt.NewWhere(condition)
 		return b
@@ -136,27 +161,33 @@ func (b Select) Where(condition stmt.Expression) Select {
 }
 
 // And adds AND WHERE conditions.
-func (b Select) And(condition stmt.Expression) Select {
+func (b Select) And(condition stmt.Expression) (next Select) {
+	defer b.catch(&next)
+
 	b.query.Where = b.query.Where.And(condition)
 	return b
 }
 
 // Or adds OR WHERE conditions.
-func (b Select) Or(condition stmt.Expression) Select {
+func (b Select) Or(condition stmt.Expression) (next Select) {
+	defer b.catch(&next)
+
 	b.query.Where = b.query.Where.Or(condition)
 	return b
 }
 
 // GroupBy adds GROUP BY clauses.
-func (b Select) GroupBy(args ...interface{}) Select {
+func (b Select) GroupBy(args ...interface{}) (next Select) {
+	defer b.catch(&next)
+
 	if !b.query.GroupBy.IsEmpty() {
-		panic("loukoum: select builder has group by clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has group by clause already defined"))
 	}
 
 	columns := ToColumns(args)
 	group := stmt.NewGroupBy(columns)
 	if group.IsEmpty() {
-		panic("loukoum: given join clause is undefined")
+		panic(types.NewError(types.ErrInvalidClause, "given join clause is undefined"))
 	}
 
 	b.query.GroupBy = group
@@ -165,9 +196,11 @@ func (b Select) GroupBy(args ...interface{}) Select {
 }
 
 // Having adds HAVING clauses.
-func (b Select) Having(condition stmt.Expression) Select {
+func (b Select) Having(condition stmt.Expression) (next Select) {
+	defer b.catch(&next)
+
 	if !b.query.Having.IsEmpty() {
-		panic("loukoum: select builder has having clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has having clause already defined"))
 	}
 
 	b.query.Having = stmt.NewHaving(condition)
@@ -175,6 +208,28 @@ func (b Select) Having(condition stmt.Expression) Select {
 	return b
 }
 
+// Window adds a named window to the WINDOW clause.
+func (b Select) Window(name string, window stmt.Window) (next Select) {
+	defer b.catch(&next)
+
+	if name == "" {
+		panic(types.NewError(types.ErrInvalidClause, "given window name is undefined"))
+	}
+	if b.query.Window.Has(name) {
+		panic(types.NewErrorf(types.ErrDuplicateClause, "select builder has window %s already defined", name))
+	}
+	if window.Name != "" && !b.query.Window.Has(window.Name) {
+		panic(types.NewErrorf(types.ErrInvalidClause, "window %s is not defined", window.Name))
+	}
+
+	windows := make([]stmt.NamedWindow, 0, len(b.query.Window.Windows)+1)
+	windows = append(windows, b.query.Window.Windows...)
+	windows = append(windows, stmt.NewNamedWindow(name, window))
+	b.query.Window = stmt.NewWindowClause(windows)
+
+	return b
+}
+
 // OrderBy adds ORDER BY clauses.
 func (b Select) OrderBy(orders ...stmt.Order) Select {
 	b.query.OrderBy.Orders = append(b.query.OrderBy.Orders, orders...)
@@ -182,14 +237,16 @@ func (b Select) OrderBy(orders ...stmt.Order) Select {
 }
 
 // Limit adds LIMIT clause.
-func (b Select) Limit(value interface{}) Select {
+func (b Select) Limit(value interface{}) (next Select) {
+	defer b.catch(&next)
+
 	if !b.query.Limit.IsEmpty() {
-		panic("loukoum: select builder has limit clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has limit clause already defined"))
 	}
 
 	limit, ok := ToInt64(value)
 	if !ok || limit <= 0 {
-		panic("loukoum: limit must be a positive integer")
+		panic(types.NewError(types.ErrInvalidLimit, "limit must be a positive integer"))
 	}
 
 	b.query.Limit = stmt.NewLimit(limit)
@@ -198,14 +255,16 @@ func (b Select) Limit(value interface{}) Select {
 }
 
 // Offset adds OFFSET clause.
-func (b Select) Offset(value interface{}) Select {
+func (b Select) Offset(value interface{}) (next Select) {
+	defer b.catch(&next)
+
 	if !b.query.Offset.IsEmpty() {
-		panic("loukoum: select builder has offset clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has offset clause already defined"))
 	}
 
 	offset, ok := ToInt64(value)
 	if !ok || offset < 0 {
-		panic("loukoum: offset must be a non-negative integer")
+		panic(types.NewError(types.ErrInvalidOffset, "offset must be a non-negative integer"))
 	}
 
 	b.query.Offset = stmt.NewOffset(offset)
@@ -213,10 +272,74 @@ func (b Select) Offset(value interface{}) Select {
 	return b
 }
 
+// ForUpdate adds a FOR UPDATE locking clause, optionally restricted to given tables.
+func (b Select) ForUpdate(tables ...interface{}) (next Select) {
+	defer b.catch(&next)
+	return b.lock(types.ForUpdate, tables)
+}
+
+// ForNoKeyUpdate adds a FOR NO KEY UPDATE locking clause, optionally restricted to given tables.
+func (b Select) ForNoKeyUpdate(tables ...interface{}) (next Select) {
+	defer b.catch(&next)
+	return b.lock(types.ForNoKeyUpdate, tables)
+}
+
+// ForShare adds a FOR SHARE locking clause, optionally restricted to given tables.
+func (b Select) ForShare(tables ...interface{}) (next Select) {
+	defer b.catch(&next)
+	return b.lock(types.ForShare, tables)
+}
+
+// ForKeyShare adds a FOR KEY SHARE locking clause, optionally restricted to given tables.
+func (b Select) ForKeyShare(tables ...interface{}) (next Select) {
+	defer b.catch(&next)
+	return b.lock(types.ForKeyShare, tables)
+}
+
+// NoWait adds a NOWAIT option to the last locking clause.
+func (b Select) NoWait() (next Select) {
+	defer b.catch(&next)
+	return b.wait(types.NoWait)
+}
+
+// SkipLocked adds a SKIP LOCKED option to the last locking clause.
+func (b Select) SkipLocked() (next Select) {
+	defer b.catch(&next)
+	return b.wait(types.SkipLocked)
+}
+
+func (b Select) lock(strength types.LockStrength, tables []interface{}) Select {
+	locks := make([]stmt.Lock, 0, len(b.query.Locks)+1)
+	locks = append(locks, b.query.Locks...)
+	b.query.Locks = append(locks, stmt.NewLock(strength, ToTables(tables)))
+
+	return b
+}
+
+func (b Select) wait(wait types.LockWait) Select {
+	last := len(b.query.Locks) - 1
+	if last < 0 {
+		panic(types.NewErrorf(types.ErrInvalidClause, "select builder has no locking clause for %s option", wait))
+	}
+	if b.query.Locks[last].Wait != "" {
+		panic(types.NewErrorf(types.ErrDuplicateClause, "select builder has %s option already defined",
+			b.query.Locks[last].Wait))
+	}
+
+	locks := make([]stmt.Lock, len(b.query.Locks))
+	copy(locks, b.query.Locks)
+	locks[last].Wait = wait
+	b.query.Locks = locks
+
+	return b
+}
+
 // Suffix adds given clauses as suffixes.
-func (b Select) Suffix(suffix interface{}) Select {
+func (b Select) Suffix(suffix interface{}) (next Select) {
+	defer b.catch(&next)
+
 	if !b.query.Offset.IsEmpty() {
-		panic("loukoum: select builder has suffixes clauses already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has suffixes clauses already defined"))
 	}
 
 	b.query.Suffix = ToSuffix(suffix)
@@ -225,9 +348,11 @@ func (b Select) Suffix(suffix interface{}) Select {
 }
 
 // Prefix adds given clauses as prefixes.
-func (b Select) Prefix(prefix interface{}) Select {
+func (b Select) Prefix(prefix interface{}) (next Select) {
+	defer b.catch(&next)
+
 	if !b.query.Offset.IsEmpty() {
-		panic("loukoum: select builder has prefixes clauses already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "select builder has prefixes clauses already defined"))
 	}
 
 	b.query.Prefix = ToPrefix(prefix)
@@ -235,33 +360,112 @@ func (b Select) Prefix(prefix interface{}) Select {
 	return b
 }
 
+// Union combines the result of the query with given query, which is a Select or Compound builder,
+// removing duplicate rows.
+func (b Select) Union(query interface{}) Compound {
+	return NewCompound(b, types.Union, query)
+}
+
+// UnionAll combines the result of the query with given query, which is a Select or Compound builder.
+func (b Select) UnionAll(query interface{}) Compound {
+	return NewCompound(b, types.UnionAll, query)
+}
+
+// Intersect returns rows that are in the result of both the query and given query,
+// which is a Select or Compound builder.
+func (b Select) Intersect(query interface{}) Compound {
+	return NewCompound(b, types.Intersect, query)
+}
+
+// Except returns rows that are in the result of the query but not in the result of given query,
+// which is a Select or Compound builder.
+func (b Select) Except(query interface{}) Compound {
+	return NewCompound(b, types.Except, query)
+}
+
+// Dialect defines the dialect used to generate the query.
+func (b Select) Dialect(dialect types.Dialect) Select {
+	b.dialect = dialect
+	return b
+}
+
 // String returns the underlying query as a raw statement.
 // This function should be used for debugging since it doesn't escape anything and is completely
 // vulnerable to SQL injection.
 // You should use either NamedQuery() or Query()...
+// If the query is invalid, its errors are returned instead.
 func (b Select) String() string {
-	ctx := &types.RawContext{}
-	b.query.Write(ctx)
-	return ctx.Query()
+	return toString(b.dialect, b.query, b.errs)
 }
 
 // NamedQuery returns the underlying query as a named statement.
+// It panics if the query is invalid: use BuildNamed() to handle errors.
 func (b Select) NamedQuery() (string, map[string]interface{}) {
-	ctx := &types.NamedContext{}
-	b.query.Write(ctx)
-	return ctx.Query(), ctx.Values()
+	return mustBuildNamed(b.BuildNamed())
 }
 
 // Query returns the underlying query as a regular statement.
+// It panics if the query is invalid: use Build() to handle errors.
 func (b Select) Query() (string, []interface{}) {
-	ctx := &types.StdContext{}
-	b.query.Write(ctx)
-	return ctx.Query(), ctx.Values()
+	return mustBuild(b.Build())
+}
+
+// BuildNamed returns the underlying query as a named statement,
+// or every error found while building and writing the query.
+func (b Select) BuildNamed() (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, types.IndexNaming)
+}
+
+// Build returns the underlying query as a regular statement,
+// or every error found while building and writing the query.
+func (b Select) Build() (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, types.PlaceholderStyle{})
+}
+
+// NamedQueryWith returns the underlying query as a named statement, using given naming style for its arguments.
+// It panics if the query is invalid: use BuildNamedWith() to handle errors.
+func (b Select) NamedQueryWith(style types.NamingStyle) (string, map[string]interface{}) {
+	return mustBuildNamed(b.BuildNamedWith(style))
+}
+
+// BuildNamedWith returns the underlying query as a named statement, using given naming style for its arguments,
+// or every error found while building and writing the query.
+func (b Select) BuildNamedWith(style types.NamingStyle) (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, style)
+}
+
+// QueryWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect.
+// It panics if the query is invalid: use BuildWith() to handle errors.
+func (b Select) QueryWith(style types.PlaceholderStyle) (string, []interface{}) {
+	return mustBuild(b.BuildWith(style))
+}
+
+// BuildWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect, or every error found while building and writing the query.
+func (b Select) BuildWith(style types.PlaceholderStyle) (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, style)
 }
 
-// Statement returns underlying statement.
+// Statement returns underlying statement, which reports errors of the builder when it's written.
 func (b Select) Statement() stmt.Statement {
-	return b.query
+	query := b.query
+	query.Errors = b.errs
+	return query
+}
+
+// Err returns errors found while building the query, or nil if every clause is valid.
+func (b Select) Err() error {
+	return b.errs.Err()
+}
+
+// catch records an error raised by a builder method and returns the builder as it was before the call.
+func (b Select) catch(next *Select) {
+	err := recoverError(recover())
+	if err != nil {
+		b.errs = types.AppendError(b.errs, err)
+		*next = b
+	}
 }
 
 func handleSelectJoin(args []interface{}) stmt.Join {
@@ -274,7 +478,7 @@ func handleSelectJoin(args []interface{}) stmt.Join {
 	case stmt.Table:
 		table = value
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as table argument for join clause", args[0]))
+		panic(types.NewErrorf(types.ErrInvalidTable, "cannot use %T as table argument for join clause", args[0]))
 	}
 
 	switch value := args[1].(type) {
@@ -285,7 +489,7 @@ func handleSelectJoin(args []interface{}) stmt.Join {
 	case stmt.InfixOnExpression:
 		join = stmt.NewInnerJoin(table, value)
 	default:
-		panic(fmt.Sprintf("loukoum: cannot use %T as condition for join clause", args[1]))
+		panic(types.NewErrorf(types.ErrInvalidClause, "cannot use %T as condition for join clause", args[1]))
 	}
 
 	join.Table = table
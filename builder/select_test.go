@@ -7,6 +7,7 @@ import (
 	"github.com/ulule/loukoum/v3"
 	"github.com/ulule/loukoum/v3/builder"
 	"github.com/ulule/loukoum/v3/stmt"
+	"github.com/ulule/loukoum/v3/types"
 )
 
 func TestSelect_Columns(t *testing.T) {
@@ -124,6 +125,93 @@ func TestSelect_Columns(t *testing.T) {
 	})
 }
 
+func TestSelect_DistinctOn(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Latest row per group",
+			Builder: loukoum.
+				Select("user_id", "id", "created_at").
+				DistinctOn("user_id").
+				From("comments").
+				OrderBy(loukoum.Order("user_id"), loukoum.Order("created_at", loukoum.Desc)),
+			SameQuery: fmt.Sprint(
+				"SELECT DISTINCT ON (user_id) user_id, id, created_at FROM comments ",
+				"ORDER BY user_id ASC, created_at DESC",
+			),
+		},
+		{
+			Name: "Without order",
+			Builders: []builder.Builder{
+				loukoum.Select("*").DistinctOn("a", "b").From("t"),
+				loukoum.Select("*").Distinct().DistinctOn(loukoum.Column("a"), loukoum.Condition("b")).From("t"),
+			},
+			SameQuery: "SELECT DISTINCT ON (a, b) * FROM t",
+		},
+		{
+			Name: "Expressions",
+			Builder: loukoum.
+				Select("*").
+				DistinctOn(loukoum.Func("date_trunc", "day", loukoum.Column("created_at")), "user_id").
+				From("events").
+				OrderBy(
+					loukoum.Order("user_id"),
+					loukoum.Func("date_trunc", "day", loukoum.Column("created_at")).Asc(),
+					loukoum.Order("created_at", loukoum.Desc),
+				),
+			String: fmt.Sprint(
+				"SELECT DISTINCT ON (date_trunc('day', created_at), user_id) * FROM events ",
+				"ORDER BY user_id ASC, date_trunc('day', created_at) ASC, created_at DESC",
+			),
+			Query: fmt.Sprint(
+				"SELECT DISTINCT ON (date_trunc($1, created_at), user_id) * FROM events ",
+				"ORDER BY user_id ASC, date_trunc($2, created_at) ASC, created_at DESC",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT DISTINCT ON (date_trunc(:arg_1, created_at), user_id) * FROM events ",
+				"ORDER BY user_id ASC, date_trunc(:arg_2, created_at) ASC, created_at DESC",
+			),
+			Args: []interface{}{"day", "day"},
+		},
+		{
+			Name: "Partial order",
+			Builder: loukoum.
+				Select("*").
+				DistinctOn("a", "b").
+				From("t").
+				OrderBy(loukoum.Order("b")),
+			SameQuery: "SELECT DISTINCT ON (a, b) * FROM t ORDER BY b ASC",
+		},
+		{
+			Name: "Mismatched order",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("user_id", "id").
+					DistinctOn("user_id").
+					From("comments").
+					OrderBy(loukoum.Order("created_at", loukoum.Desc), loukoum.Order("user_id"))
+			},
+		},
+		{
+			Name: "Undefined",
+			Failure: func() builder.Builder {
+				return loukoum.Select("*").DistinctOn().From("t")
+			},
+		},
+		{
+			Name: "Duplicate",
+			Failure: func() builder.Builder {
+				return loukoum.Select("*").DistinctOn("a").DistinctOn("b").From("t")
+			},
+		},
+		{
+			Name: "MySQL",
+			Failure: func() builder.Builder {
+				return loukoum.Select("*").DistinctOn("a").From("t").Dialect(loukoum.MySQL)
+			},
+		},
+	})
+}
+
 func TestSelect_From(t *testing.T) {
 	RunBuilderTests(t, []BuilderTest{
 		{
@@ -997,6 +1085,445 @@ func TestSelect_NotExists(t *testing.T) {
 	})
 }
 
+func TestSelect_Arithmetic(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Alias",
+			Builder: loukoum.
+				Select("id", loukoum.Condition("price").Multiply(loukoum.Condition("quantity")).As("total")).
+				From("items"),
+			SameQuery: "SELECT id, (price * quantity) AS total FROM items",
+		},
+		{
+			Name: "Comparison",
+			Builder: loukoum.
+				Select("id").
+				From("items").
+				Where(loukoum.Condition("price").Multiply(loukoum.Condition("quantity")).GreaterThan(100)).
+				And(loukoum.Condition("id").Modulo(2).Equal(0)),
+			String:     "SELECT id FROM items WHERE (((price * quantity) > 100) AND ((id % 2) = 0))",
+			Query:      "SELECT id FROM items WHERE (((price * quantity) > $1) AND ((id % $2) = $3))",
+			NamedQuery: "SELECT id FROM items WHERE (((price * quantity) > :arg_1) AND ((id % :arg_2) = :arg_3))",
+			Args:       []interface{}{100, 2, 0},
+		},
+		{
+			Name: "Operators",
+			Builder: loukoum.
+				Select(
+					loukoum.Condition("a").Add(1).Subtract(2).Divide(3),
+					loukoum.Condition("first_name").Concat(loukoum.Condition("last_name")),
+					loukoum.Condition("flags").BitwiseAnd(4).BitwiseOr(8),
+					loukoum.Condition("flags").BitwiseShiftLeft(1).BitwiseShiftRight(2),
+				).
+				From("test"),
+			String: fmt.Sprint(
+				"SELECT (((a + 1) - 2) / 3), (first_name || last_name), ((flags & 4) | 8), ",
+				"((flags << 1) >> 2) FROM test",
+			),
+			Query: fmt.Sprint(
+				"SELECT (((a + $1) - $2) / $3), (first_name || last_name), ((flags & $4) | $5), ",
+				"((flags << $6) >> $7) FROM test",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT (((a + :arg_1) - :arg_2) / :arg_3), (first_name || last_name), ((flags & :arg_4) | :arg_5), ",
+				"((flags << :arg_6) >> :arg_7) FROM test",
+			),
+			Args: []interface{}{1, 2, 3, 4, 8, 1, 2},
+		},
+		{
+			Name: "Unary",
+			Builder: loukoum.
+				Select(loukoum.Negate(loukoum.Condition("balance")).As("debt"), loukoum.BitwiseNot(-1)).
+				From("accounts").
+				Where(loukoum.Condition("credit").Subtract(loukoum.Negate(loukoum.Condition("balance").Add(10))).GreaterThan(0)),
+			String: "SELECT (-balance) AS debt, (~(-1)) FROM accounts WHERE ((credit - (-(balance + 10))) > 0)",
+			Query:  "SELECT (-balance) AS debt, (~($1)) FROM accounts WHERE ((credit - (-(balance + $2))) > $3)",
+			NamedQuery: fmt.Sprint(
+				"SELECT (-balance) AS debt, (~(:arg_1)) FROM accounts ",
+				"WHERE ((credit - (-(balance + :arg_2))) > :arg_3)",
+			),
+			Args: []interface{}{-1, 10, 0},
+		},
+		{
+			Name: "Subquery",
+			Builder: loukoum.
+				Select(loukoum.Condition("price").Subtract(loukoum.Select("AVG(price)").From("items")).As("delta")).
+				From("items"),
+			SameQuery: "SELECT (price - (SELECT AVG(price) FROM items)) AS delta FROM items",
+		},
+		{
+			Name: "Aliased operands",
+			Builder: loukoum.
+				Select(
+					loukoum.Condition("price").Multiply(loukoum.Condition("quantity")).As("total").
+						Add(loukoum.Func("coalesce", loukoum.Column("fee"), loukoum.Raw("0")).As("fee")).
+						As("amount"),
+					loukoum.Negate(loukoum.Func("abs", loukoum.Column("balance")).As("balance")),
+				).
+				From("items").
+				Where(loukoum.Func("lower", loukoum.Column("name")).As("name").Equal(loukoum.Raw("'book'"))),
+			SameQuery: fmt.Sprint(
+				"SELECT ((price * quantity) + coalesce(fee, 0)) AS amount, (-abs(balance)) ",
+				"FROM items WHERE (lower(name) = 'book')",
+			),
+		},
+	})
+}
+
+func TestSelect_Func(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Where",
+			Builder: loukoum.
+				Select("id").
+				From("users").
+				Where(loukoum.Func("lower", loukoum.Column("email")).Equal("tom@ulule.com")),
+			String:     "SELECT id FROM users WHERE (lower(email) = 'tom@ulule.com')",
+			Query:      "SELECT id FROM users WHERE (lower(email) = $1)",
+			NamedQuery: "SELECT id FROM users WHERE (lower(email) = :arg_1)",
+			Args:       []interface{}{"tom@ulule.com"},
+		},
+		{
+			Name: "Alias",
+			Builder: loukoum.
+				Select(
+					loukoum.Func("date_trunc", "day", loukoum.Column("created_at")).As("day"),
+					loukoum.Func("coalesce", loukoum.Column("nickname"), loukoum.Column("username")).As("name"),
+					loukoum.Func("now"),
+				).
+				From("users"),
+			String: fmt.Sprint(
+				"SELECT date_trunc('day', created_at) AS day, coalesce(nickname, username) AS name, now() ",
+				"FROM users",
+			),
+			Query: fmt.Sprint(
+				"SELECT date_trunc($1, created_at) AS day, coalesce(nickname, username) AS name, now() ",
+				"FROM users",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT date_trunc(:arg_1, created_at) AS day, coalesce(nickname, username) AS name, now() ",
+				"FROM users",
+			),
+			Args: []interface{}{"day"},
+		},
+		{
+			Name: "Nested",
+			Builder: loukoum.
+				Select(loukoum.Func("coalesce", loukoum.Func("max", loukoum.Column("score")), 0).Add(1)).
+				From("games").
+				Where(loukoum.Func("length", loukoum.Func("trim", loukoum.Column("name"))).GreaterThan(3)),
+			String:     "SELECT (coalesce(max(score), 0) + 1) FROM games WHERE (length(trim(name)) > 3)",
+			Query:      "SELECT (coalesce(max(score), $1) + $2) FROM games WHERE (length(trim(name)) > $3)",
+			NamedQuery: "SELECT (coalesce(max(score), :arg_1) + :arg_2) FROM games WHERE (length(trim(name)) > :arg_3)",
+			Args:       []interface{}{0, 1, 3},
+		},
+		{
+			Name: "Subquery",
+			Builder: loukoum.
+				Select(loukoum.Func("coalesce", loukoum.Select("MAX(id)").From("users"), 0)).
+				From("dual"),
+			String:     "SELECT coalesce((SELECT MAX(id) FROM users), 0) FROM dual",
+			Query:      "SELECT coalesce((SELECT MAX(id) FROM users), $1) FROM dual",
+			NamedQuery: "SELECT coalesce((SELECT MAX(id) FROM users), :arg_1) FROM dual",
+			Args:       []interface{}{0},
+		},
+		{
+			Name: "Order by",
+			Builder: loukoum.
+				Select("id").
+				From("users").
+				OrderBy(loukoum.Func("lower", loukoum.Column("last_name")).Asc(), loukoum.Order("id", loukoum.Desc)),
+			SameQuery: "SELECT id FROM users ORDER BY lower(last_name) ASC, id DESC",
+		},
+		{
+			Name: "Order by alias",
+			Builder: loukoum.
+				Select(loukoum.Func("lower", loukoum.Column("last_name")).As("name")).
+				From("users").
+				OrderBy(loukoum.Func("lower", loukoum.Column("last_name")).As("name").Desc()),
+			SameQuery: "SELECT lower(last_name) AS name FROM users ORDER BY name DESC",
+		},
+		{
+			Name: "Invalid name",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Func("pg_sleep(10); --")).
+					From("users")
+			},
+		},
+	})
+}
+
+func TestSelect_Case(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Searched",
+			Builder: loukoum.
+				Select("id", loukoum.Case().
+					When(loukoum.Condition("score").GreaterThanOrEqual(90), "gold").
+					When(loukoum.Condition("score").GreaterThanOrEqual(50), "silver").
+					Else(loukoum.Column("default_label")).
+					As("label"),
+				).
+				From("players"),
+			String: fmt.Sprint(
+				"SELECT id, CASE WHEN (score >= 90) THEN 'gold' WHEN (score >= 50) THEN 'silver' ",
+				"ELSE default_label END AS label FROM players",
+			),
+			Query: fmt.Sprint(
+				"SELECT id, CASE WHEN (score >= $1) THEN $2 WHEN (score >= $3) THEN $4 ",
+				"ELSE default_label END AS label FROM players",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT id, CASE WHEN (score >= :arg_1) THEN :arg_2 WHEN (score >= :arg_3) THEN :arg_4 ",
+				"ELSE default_label END AS label FROM players",
+			),
+			Args: []interface{}{90, "gold", 50, "silver"},
+		},
+		{
+			Name: "Simple",
+			Builder: loukoum.
+				Select("id", loukoum.CaseOf(loukoum.Column("status")).When("draft", 1).When("published", 2).As("rank")).
+				From("news"),
+			String:     "SELECT id, CASE status WHEN 'draft' THEN 1 WHEN 'published' THEN 2 END AS rank FROM news",
+			Query:      "SELECT id, CASE status WHEN $1 THEN $2 WHEN $3 THEN $4 END AS rank FROM news",
+			NamedQuery: "SELECT id, CASE status WHEN :arg_1 THEN :arg_2 WHEN :arg_3 THEN :arg_4 END AS rank FROM news",
+			Args:       []interface{}{"draft", 1, "published", 2},
+		},
+		{
+			Name: "Order by",
+			Builder: loukoum.
+				Select("id").
+				From("news").
+				OrderBy(
+					loukoum.CaseOf(loukoum.Column("status")).When("published", 0).Else(1).Asc(),
+					loukoum.Order("id", loukoum.Desc),
+				),
+			String:     "SELECT id FROM news ORDER BY CASE status WHEN 'published' THEN 0 ELSE 1 END ASC, id DESC",
+			Query:      "SELECT id FROM news ORDER BY CASE status WHEN $1 THEN $2 ELSE $3 END ASC, id DESC",
+			NamedQuery: "SELECT id FROM news ORDER BY CASE status WHEN :arg_1 THEN :arg_2 ELSE :arg_3 END ASC, id DESC",
+			Args:       []interface{}{"published", 0, 1},
+		},
+		{
+			Name: "Undefined",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id").
+					From("news").
+					OrderBy(loukoum.Case().Else(1).Asc())
+			},
+		},
+	})
+}
+
+func TestSelect_Window(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Row number",
+			Builder: loukoum.
+				Select("id", loukoum.Func("row_number").Over(
+					loukoum.Window().PartitionBy("user_id").OrderBy(loukoum.Order("created_at", loukoum.Desc)),
+				).As("rank")).
+				From("comments"),
+			SameQuery: fmt.Sprint(
+				"SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY created_at DESC) AS rank ",
+				"FROM comments",
+			),
+		},
+		{
+			Name: "Empty",
+			Builder: loukoum.
+				Select("id", loukoum.Count("*").Over(loukoum.Window()).As("total")).
+				From("comments"),
+			SameQuery: "SELECT id, COUNT(*) OVER () AS total FROM comments",
+		},
+		{
+			Name: "Running sum",
+			Builder: loukoum.
+				Select("id", loukoum.Sum("amount").Over(
+					loukoum.Window().
+						PartitionBy(loukoum.Column("account_id"), loukoum.Func("date_trunc", "month", loukoum.Column("date"))).
+						OrderBy(loukoum.Order("date")).
+						Rows(loukoum.UnboundedPreceding(), loukoum.CurrentRow()),
+				)).
+				From("transactions"),
+			String: fmt.Sprint(
+				"SELECT id, SUM(amount) OVER (PARTITION BY account_id, date_trunc('month', date) ORDER BY date ASC ",
+				"ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM transactions",
+			),
+			Query: fmt.Sprint(
+				"SELECT id, SUM(amount) OVER (PARTITION BY account_id, date_trunc($1, date) ORDER BY date ASC ",
+				"ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM transactions",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT id, SUM(amount) OVER (PARTITION BY account_id, date_trunc(:arg_1, date) ORDER BY date ASC ",
+				"ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM transactions",
+			),
+			Args: []interface{}{"month"},
+		},
+		{
+			Name: "Lag and lead",
+			Builder: loukoum.
+				Select(
+					loukoum.Func("lag", loukoum.Column("price"), 1).Over(loukoum.Window().Base("w")).As("previous"),
+					loukoum.Func("lead", loukoum.Column("price"), 1).Over(loukoum.Window().Base("w")).As("next"),
+					loukoum.Max("price").Over(loukoum.Window().Base("w").Range(loukoum.Preceding(10), loukoum.Following(10))),
+					loukoum.Min("price").Over(loukoum.Window().Base("w").Rows(loukoum.Preceding(2))),
+				).
+				From("prices").
+				Window("w", loukoum.Window().PartitionBy("product_id").OrderBy(loukoum.Order("day"))),
+			String: fmt.Sprint(
+				"SELECT lag(price, 1) OVER w AS previous, lead(price, 1) OVER w AS next, ",
+				"MAX(price) OVER (w RANGE BETWEEN 10 PRECEDING AND 10 FOLLOWING), ",
+				"MIN(price) OVER (w ROWS 2 PRECEDING) ",
+				"FROM prices WINDOW w AS (PARTITION BY product_id ORDER BY day ASC)",
+			),
+			Query: fmt.Sprint(
+				"SELECT lag(price, $1) OVER w AS previous, lead(price, $2) OVER w AS next, ",
+				"MAX(price) OVER (w RANGE BETWEEN 10 PRECEDING AND 10 FOLLOWING), ",
+				"MIN(price) OVER (w ROWS 2 PRECEDING) ",
+				"FROM prices WINDOW w AS (PARTITION BY product_id ORDER BY day ASC)",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT lag(price, :arg_1) OVER w AS previous, lead(price, :arg_2) OVER w AS next, ",
+				"MAX(price) OVER (w RANGE BETWEEN 10 PRECEDING AND 10 FOLLOWING), ",
+				"MIN(price) OVER (w ROWS 2 PRECEDING) ",
+				"FROM prices WINDOW w AS (PARTITION BY product_id ORDER BY day ASC)",
+			),
+			Args: []interface{}{1, 1},
+		},
+		{
+			Name: "Multiple windows",
+			Builder: loukoum.
+				Select(
+					loukoum.Func("rank").Over(loukoum.Window().Base("a")),
+					loukoum.Func("rank").Over(loukoum.Window().Base("b")),
+				).
+				From("scores").
+				Having(loukoum.Func("count", loukoum.Raw("*")).GreaterThan(1)).
+				Window("a", loukoum.Window().OrderBy(loukoum.Order("score", loukoum.Desc))).
+				Window("b", loukoum.Window().Base("a").Groups(loukoum.CurrentRow(), loukoum.UnboundedFollowing())).
+				OrderBy(loukoum.Order("id")),
+			String: fmt.Sprint(
+				"SELECT rank() OVER a, rank() OVER b FROM scores HAVING (count(*) > 1) ",
+				"WINDOW a AS (ORDER BY score DESC), ",
+				"b AS (a GROUPS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING) ORDER BY id ASC",
+			),
+			Query: fmt.Sprint(
+				"SELECT rank() OVER a, rank() OVER b FROM scores HAVING (count(*) > $1) ",
+				"WINDOW a AS (ORDER BY score DESC), ",
+				"b AS (a GROUPS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING) ORDER BY id ASC",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT rank() OVER a, rank() OVER b FROM scores HAVING (count(*) > :arg_1) ",
+				"WINDOW a AS (ORDER BY score DESC), ",
+				"b AS (a GROUPS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING) ORDER BY id ASC",
+			),
+			Args: []interface{}{1},
+		},
+		{
+			Name: "Duplicate window",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Func("rank").Over(loukoum.Window().Base("w"))).
+					From("scores").
+					Window("w", loukoum.Window()).
+					Window("w", loukoum.Window())
+			},
+		},
+		{
+			Name: "Inverted frame",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Sum("score").Over(loukoum.Window().Rows(loukoum.UnboundedFollowing()))).
+					From("scores")
+			},
+		},
+		{
+			Name: "Frame ending before current row",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Sum("score").Over(loukoum.Window().Rows(loukoum.CurrentRow(), loukoum.Preceding(1)))).
+					From("scores")
+			},
+		},
+		{
+			Name: "Frame starting after current row",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Sum("score").Over(loukoum.Window().Rows(loukoum.Following(1), loukoum.CurrentRow()))).
+					From("scores")
+			},
+		},
+		{
+			Name: "Frame starting after implicit end",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Sum("score").Over(loukoum.Window().Rows(loukoum.Following(1)))).
+					From("scores")
+			},
+		},
+		{
+			Name: "Unknown window",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Func("rank").Over(loukoum.Window().Base("w"))).
+					From("scores").
+					Window("v", loukoum.Window())
+			},
+		},
+		{
+			Name: "Unknown base window",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Func("rank").Over(loukoum.Window().Base("b"))).
+					From("scores").
+					Window("b", loukoum.Window().Base("a")).
+					Window("a", loukoum.Window())
+			},
+		},
+		{
+			Name: "Window of outer query",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id", loukoum.Func("rank").Over(loukoum.Window().Base("w"))).
+					From("scores").
+					Where(loukoum.Condition("id").In(
+						loukoum.Select(loukoum.Func("first_value", loukoum.Column("id")).Over(loukoum.Window().Base("w"))).
+							From("scores"),
+					)).
+					Window("w", loukoum.Window().OrderBy(loukoum.Order("score")))
+			},
+		},
+		{
+			Name: "Negative offset",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Sum("score").Over(loukoum.Window().Rows(loukoum.Preceding(-1)))).
+					From("scores")
+			},
+		},
+		{
+			Name: "Groups MySQL",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Sum("score").Over(loukoum.Window().Groups(loukoum.Preceding(1)))).
+					From("scores").
+					Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "SQLite 3.24",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Func("row_number").Over(loukoum.Window())).
+					From("scores").
+					Dialect(types.SQLiteDialect{Version: 3024000})
+			},
+		},
+	})
+}
+
 func TestSelect_GroupBy(t *testing.T) {
 	RunBuilderTests(t, []BuilderTest{
 		{
@@ -1262,6 +1789,163 @@ func TestSelect_Offset(t *testing.T) {
 	})
 }
 
+func TestSelect_Lock(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "For update",
+			Builder: loukoum.
+				Select("id").
+				From("jobs").
+				ForUpdate(),
+			SameQuery: "SELECT id FROM jobs FOR UPDATE",
+		},
+		{
+			Name: "Skip locked",
+			Builder: loukoum.
+				Select("id", "payload").
+				From("jobs").
+				Where(loukoum.Condition("status").Equal("pending")).
+				OrderBy(loukoum.Order("id")).
+				Limit(10).
+				ForUpdate().
+				SkipLocked(),
+			String: fmt.Sprint(
+				"SELECT id, payload FROM jobs WHERE (status = 'pending') ",
+				"ORDER BY id ASC LIMIT 10 FOR UPDATE SKIP LOCKED",
+			),
+			Query: fmt.Sprint(
+				"SELECT id, payload FROM jobs WHERE (status = $1) ",
+				"ORDER BY id ASC LIMIT 10 FOR UPDATE SKIP LOCKED",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT id, payload FROM jobs WHERE (status = :arg_1) ",
+				"ORDER BY id ASC LIMIT 10 FOR UPDATE SKIP LOCKED",
+			),
+			Args: []interface{}{"pending"},
+		},
+		{
+			Name:      "No key update",
+			Builder:   loukoum.Select("id").From("jobs").ForNoKeyUpdate().NoWait(),
+			SameQuery: "SELECT id FROM jobs FOR NO KEY UPDATE NOWAIT",
+		},
+		{
+			Name: "Multiple clauses",
+			Builder: loukoum.
+				Select("jobs.id", "queues.name").
+				From("jobs").
+				Join("queues", loukoum.On("jobs.queue_id", "queues.id")).
+				ForNoKeyUpdate("jobs").
+				NoWait().
+				ForKeyShare(loukoum.Table("queues")).
+				ForShare("a", "b").
+				SkipLocked(),
+			SameQuery: fmt.Sprint(
+				"SELECT jobs.id, queues.name FROM jobs INNER JOIN queues ON jobs.queue_id = queues.id ",
+				"FOR NO KEY UPDATE OF jobs NOWAIT FOR KEY SHARE OF queues FOR SHARE OF a, b SKIP LOCKED",
+			),
+		},
+		{
+			Name: "MySQL",
+			Builder: loukoum.
+				Select("id").
+				From("jobs").
+				ForShare("jobs").
+				NoWait().
+				Dialect(loukoum.MySQL),
+			SameQuery: "SELECT `id` FROM `jobs` FOR SHARE OF `jobs` NOWAIT",
+		},
+		{
+			Name: "Aliased table",
+			Builder: loukoum.
+				Select("j.id").
+				From(loukoum.Table("jobs").As("j")).
+				Join(loukoum.Table("queues").As("q"), loukoum.On("j.queue_id", "q.id")).
+				ForUpdate(loukoum.Table("jobs").As("j")).
+				ForShare("q"),
+			SameQuery: "SELECT j.id FROM jobs AS j INNER JOIN queues AS q ON j.queue_id = q.id FOR UPDATE OF j FOR SHARE OF q",
+		},
+		{
+			Name: "Without locking clause",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("jobs").SkipLocked()
+			},
+		},
+		{
+			Name: "Duplicate option",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("jobs").ForUpdate().NoWait().SkipLocked()
+			},
+		},
+		{
+			Name: "Distinct",
+			Failure: func() builder.Builder {
+				return loukoum.Select("user_id").Distinct().From("jobs").ForUpdate()
+			},
+		},
+		{
+			Name: "Group by",
+			Failure: func() builder.Builder {
+				return loukoum.Select("user_id").From("jobs").ForShare().GroupBy("user_id")
+			},
+		},
+		{
+			Name: "Scalar function",
+			Builder: loukoum.
+				Select(loukoum.Func("lower", loukoum.Column("email"))).
+				From("users").
+				ForUpdate(),
+			SameQuery: "SELECT lower(email) FROM users FOR UPDATE",
+		},
+		{
+			Name: "Aggregate function",
+			Failure: func() builder.Builder {
+				return loukoum.Select(loukoum.Count("id")).From("jobs").ForUpdate()
+			},
+		},
+		{
+			Name: "Nested aggregate function",
+			Failure: func() builder.Builder {
+				return loukoum.Select(loukoum.Func("coalesce", loukoum.Func("MAX", loukoum.Column("id")), 0)).
+					From("jobs").
+					ForUpdate()
+			},
+		},
+		{
+			Name: "Window function",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id", loukoum.Func("row_number").Over(loukoum.Window().OrderBy(loukoum.Order("id")))).
+					From("jobs").
+					ForUpdate()
+			},
+		},
+		{
+			Name: "Compound operand",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("jobs").ForUpdate().Union(loukoum.Select("id").From("archives"))
+			},
+		},
+		{
+			Name: "Right compound operand",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("jobs").Union(loukoum.Select("id").From("archives").ForShare())
+			},
+		},
+		{
+			Name: "Key locking on MySQL",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("jobs").ForKeyShare().Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "SQLite",
+			Failure: func() builder.Builder {
+				return loukoum.Select("id").From("jobs").ForUpdate().Dialect(loukoum.SQLite)
+			},
+		},
+	})
+}
+
 func TestSelect_With(t *testing.T) {
 	RunBuilderTests(t, []BuilderTest{
 		{
@@ -1364,6 +2048,83 @@ func TestSelect_With(t *testing.T) {
 			),
 			Args: []interface{}{0, 10},
 		},
+		{
+			Name: "Recursive with statement",
+			Builder: loukoum.
+				Select("id", "name", "depth").
+				From("tree").
+				With(loukoum.With("tree",
+					loukoum.Select("id", "name", "1").
+						From("categories").
+						Where(loukoum.Condition("id").Equal(42)).
+						UnionAll(
+							loukoum.Select("categories.id", "categories.name", "tree.depth + 1").
+								From("categories").
+								Join("tree", loukoum.On("categories.parent_id", "tree.id")).
+								Where(loukoum.Condition("tree.depth").LessThan(5)),
+						),
+				).Columns("id", "name", "depth").Recursive()).
+				Where(loukoum.Condition("depth").GreaterThan(1)),
+			String: fmt.Sprint(
+				"WITH RECURSIVE tree(id, name, depth) AS (SELECT id, name, 1 FROM categories WHERE (id = 42) ",
+				"UNION ALL SELECT categories.id, categories.name, tree.depth + 1 FROM categories ",
+				"INNER JOIN tree ON categories.parent_id = tree.id WHERE (tree.depth < 5)) ",
+				"SELECT id, name, depth FROM tree WHERE (depth > 1)",
+			),
+			Query: fmt.Sprint(
+				"WITH RECURSIVE tree(id, name, depth) AS (SELECT id, name, 1 FROM categories WHERE (id = $1) ",
+				"UNION ALL SELECT categories.id, categories.name, tree.depth + 1 FROM categories ",
+				"INNER JOIN tree ON categories.parent_id = tree.id WHERE (tree.depth < $2)) ",
+				"SELECT id, name, depth FROM tree WHERE (depth > $3)",
+			),
+			NamedQuery: fmt.Sprint(
+				"WITH RECURSIVE tree(id, name, depth) AS (SELECT id, name, 1 FROM categories WHERE (id = :arg_1) ",
+				"UNION ALL SELECT categories.id, categories.name, tree.depth + 1 FROM categories ",
+				"INNER JOIN tree ON categories.parent_id = tree.id WHERE (tree.depth < :arg_2)) ",
+				"SELECT id, name, depth FROM tree WHERE (depth > :arg_3)",
+			),
+			Args: []interface{}{42, 5, 1},
+		},
+		{
+			Name: "Materialized with statement",
+			Builder: loukoum.
+				Select("*").
+				From("a").
+				With(loukoum.With("a", loukoum.Select("id").From("users")).Materialized()).
+				With(loukoum.With("b", loukoum.Select("id").From("comments")).NotMaterialized()),
+			SameQuery: fmt.Sprint(
+				"WITH a AS MATERIALIZED (SELECT id FROM users), ",
+				"b AS NOT MATERIALIZED (SELECT id FROM comments) SELECT * FROM a",
+			),
+		},
+		{
+			Name: "Data-modifying with statement",
+			Builder: loukoum.
+				Select("*").
+				From("moved").
+				With(loukoum.With("moved", loukoum.
+					Delete("comments").
+					Where(loukoum.Condition("deleted_at").IsNull(false)).
+					Returning("*"),
+				)),
+			SameQuery: "WITH moved AS (DELETE FROM comments WHERE (deleted_at IS NOT NULL) RETURNING *) SELECT * FROM moved",
+		},
+		{
+			Name: "Empty with statement",
+			Failure: func() builder.Builder {
+				return loukoum.Select("*").From("a").With(loukoum.With("a", stmt.NewSelect()))
+			},
+		},
+		{
+			Name: "Materialized with statement on MySQL",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("*").
+					From("a").
+					With(loukoum.With("a", loukoum.Select("id").From("users")).Materialized()).
+					Dialect(loukoum.MySQL)
+			},
+		},
 	})
 }
 
@@ -1387,3 +2148,245 @@ func TestSelect_Extra(t *testing.T) {
 		},
 	})
 }
+
+func TestSelect_MySQL(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Simple",
+			Builder: loukoum.
+				Select("id", "first_name", loukoum.Column("u.email").As("mail")).
+				From(loukoum.Table("users").As("u")).
+				Join("profiles", "profiles.user_id = u.id").
+				Where(loukoum.Condition("deleted_at").IsNull(true)).
+				And(loukoum.Condition("first_name").Equal("Tom")).
+				OrderBy(loukoum.Order("id", loukoum.Desc)).
+				Limit(10).
+				Dialect(loukoum.MySQL),
+			String: fmt.Sprint(
+				"SELECT `id`, `first_name`, `u`.`email` AS `mail` FROM `users` AS `u` ",
+				"INNER JOIN `profiles` ON `profiles`.`user_id` = `u`.`id` ",
+				"WHERE ((`deleted_at` IS NULL) AND (`first_name` = 'Tom')) ORDER BY `id` DESC LIMIT 10",
+			),
+			Query: fmt.Sprint(
+				"SELECT `id`, `first_name`, `u`.`email` AS `mail` FROM `users` AS `u` ",
+				"INNER JOIN `profiles` ON `profiles`.`user_id` = `u`.`id` ",
+				"WHERE ((`deleted_at` IS NULL) AND (`first_name` = ?)) ORDER BY `id` DESC LIMIT 10",
+			),
+			NamedQuery: fmt.Sprint(
+				"SELECT `id`, `first_name`, `u`.`email` AS `mail` FROM `users` AS `u` ",
+				"INNER JOIN `profiles` ON `profiles`.`user_id` = `u`.`id` ",
+				"WHERE ((`deleted_at` IS NULL) AND (`first_name` = :arg_1)) ORDER BY `id` DESC LIMIT 10",
+			),
+			Args: []interface{}{"Tom"},
+		},
+		{
+			Name: "Expressions",
+			Builder: loukoum.
+				Select("*", "t.*", "COUNT(*)", loukoum.Count("id")).
+				From("test").
+				Where(loukoum.Condition("id").In(1, 2)).
+				Dialect(loukoum.MySQL),
+			String:     "SELECT *, `t`.*, COUNT(*), COUNT(id) FROM `test` WHERE (`id` IN (1, 2))",
+			Query:      "SELECT *, `t`.*, COUNT(*), COUNT(id) FROM `test` WHERE (`id` IN (?, ?))",
+			NamedQuery: "SELECT *, `t`.*, COUNT(*), COUNT(id) FROM `test` WHERE (`id` IN (:arg_1, :arg_2))",
+			Args:       []interface{}{1, 2},
+		},
+		{
+			Name: "ILike",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id").
+					From("users").
+					Where(loukoum.Condition("email").ILike("%@ulule.com")).
+					Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "Only",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id").
+					From(stmt.NewFrom(loukoum.Table("users"), true)).
+					Dialect(loukoum.MySQL)
+			},
+		},
+	})
+}
+
+func TestSelect_SQLite(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Simple",
+			Builder: loukoum.
+				Select("id").
+				From("users").
+				Where(loukoum.Condition("email").Like("%@ulule.com")).
+				And(loukoum.Condition("id").In(1, 2)).
+				Dialect(loukoum.SQLite),
+			String:     "SELECT id FROM users WHERE ((email LIKE '%@ulule.com') AND (id IN (1, 2)))",
+			Query:      "SELECT id FROM users WHERE ((email LIKE ?1) AND (id IN (?2, ?3)))",
+			NamedQuery: "SELECT id FROM users WHERE ((email LIKE :arg_1) AND (id IN (:arg_2, :arg_3)))",
+			Args:       []interface{}{"%@ulule.com", 1, 2},
+		},
+		{
+			Name: "ILike",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id").
+					From("users").
+					Where(loukoum.Condition("email").NotILike("%@ulule.com")).
+					Dialect(loukoum.SQLite)
+			},
+		},
+		{
+			Name: "Only",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id").
+					From(stmt.NewFrom(loukoum.Table("users"), true)).
+					Dialect(loukoum.SQLite)
+			},
+		},
+	})
+}
+
+func TestSelect_Quoting(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "When needed",
+			Builder: loukoum.
+				Select("id", "user.name", "Email", "order", "COUNT(*)", `"Weird ""name"""`).
+				From(loukoum.Table("public.user").As("u")).
+				Join("orders", "orders.user_id = u.id").
+				OrderBy(loukoum.Order("user.createdAt", loukoum.Desc)).
+				Dialect(types.PostgreSQLDialect{Quoting: loukoum.QuoteWhenNeeded}),
+			SameQuery: fmt.Sprint(
+				`SELECT id, "user".name, "Email", "order", COUNT(*), "Weird ""name""" `,
+				`FROM public."user" AS u INNER JOIN orders ON orders.user_id = u.id `,
+				`ORDER BY "user"."createdAt" DESC`,
+			),
+		},
+		{
+			Name: "Always",
+			Builder: loukoum.
+				Select("ID", "firstName", "u.*", loukoum.Column("email").As("Mail")).
+				From(loukoum.Table("users").As("u")).
+				Dialect(types.PostgreSQLDialect{Quoting: loukoum.QuoteAlways}),
+			SameQuery: `SELECT "id", "firstName", "u".*, "email" AS "Mail" FROM "users" AS "u"`,
+		},
+		{
+			Name: "Always MySQL",
+			Builder: loukoum.
+				Select("ID", "`order`", `"user"."name"`).
+				From("users").
+				Dialect(loukoum.MySQL),
+			SameQuery: "SELECT `ID`, `order`, `user`.`name` FROM `users`",
+		},
+		{
+			Name: "Strict",
+			Builder: loukoum.
+				Select("id", "u.name", "u.*").
+				From(loukoum.Table("users").As("u")).
+				Where(loukoum.Condition("u.id").Equal(1)).
+				OrderBy(loukoum.Order("created_at")).
+				Dialect(types.PostgreSQLDialect{Strict: true}),
+			String:     "SELECT id, u.name, u.* FROM users AS u WHERE (u.id = 1) ORDER BY created_at ASC",
+			Query:      "SELECT id, u.name, u.* FROM users AS u WHERE (u.id = $1) ORDER BY created_at ASC",
+			NamedQuery: "SELECT id, u.name, u.* FROM users AS u WHERE (u.id = :arg_1) ORDER BY created_at ASC",
+			Args:       []interface{}{1},
+		},
+		{
+			Name: "Dotted alias",
+			Builders: []builder.Builder{
+				loukoum.Select(loukoum.Column("u.id").As("author.id")).
+					From(loukoum.Table("users").As("u")),
+				loukoum.Select(loukoum.Column("u.id").As("author.id")).
+					From(loukoum.Table("users").As("u")).
+					Dialect(types.PostgreSQLDialect{Strict: true}),
+			},
+			SameQuery: `SELECT u.id AS "author.id" FROM users AS u`,
+		},
+		{
+			Name: "Strict expression",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id", "COUNT(*)").
+					From("users").
+					Dialect(types.PostgreSQLDialect{Strict: true})
+			},
+		},
+		{
+			Name: "Strict order",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id").
+					From("users").
+					OrderBy(loukoum.Order("id; DROP TABLE users")).
+					Dialect(types.PostgreSQLDialect{Strict: true})
+			},
+		},
+		{
+			Name: "Strict columns",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select("id, (SELECT password FROM users LIMIT 1)").
+					From("users").
+					Dialect(types.MySQLDialect{Strict: true})
+			},
+		},
+		{
+			Name: "Strict alias",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(loukoum.Column("id").As("u.id; --")).
+					From("users").
+					Dialect(types.SQLiteDialect{Strict: true})
+			},
+		},
+		{
+			Name: "Strict quoted",
+			Failure: func() builder.Builder {
+				return loukoum.
+					Select(`"id"`).
+					From("users").
+					Dialect(types.PostgreSQLDialect{Quoting: loukoum.QuoteAlways, Strict: true})
+			},
+		},
+	})
+}
+
+func BenchmarkSelect_Query(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = loukoum.Select("id", "email", "status", "created_at").
+			From("users").
+			Where(loukoum.Condition("id").Equal(i)).
+			And(loukoum.Condition("deleted_at").IsNull(true)).
+			And(loukoum.Condition("status").In("active", "pending")).
+			OrderBy(loukoum.Order("created_at", loukoum.Desc)).
+			Limit(10).
+			Query()
+	}
+}
+
+func BenchmarkSelect_Template(b *testing.B) {
+	b.ReportAllocs()
+
+	template, err := loukoum.Compile(loukoum.Select("id", "email", "status", "created_at").
+		From("users").
+		Where(loukoum.Condition("id").Equal(loukoum.Param("id"))).
+		And(loukoum.Condition("deleted_at").IsNull(true)).
+		And(loukoum.Condition("status").In("active", "pending")).
+		OrderBy(loukoum.Order("created_at", loukoum.Desc)).
+		Limit(10))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = template.Query(map[string]interface{}{"id": i})
+	}
+}
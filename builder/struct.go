@@ -0,0 +1,301 @@
+package builder
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ulule/loukoum/v3/stmt"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// structField is a field of a struct mapped to a column.
+type structField struct {
+	// Column is the column name, prefixed by the names of its parent fields if it belongs to a nested struct.
+	Column string
+	// Index is the index sequence of the field, used by reflect.Value.FieldByIndex.
+	Index []int
+	// OmitEmpty is true if the field is ignored when it has a zero value.
+	OmitEmpty bool
+	// Nested is true if the field belongs to a named struct field, instead of an embedded struct.
+	Nested bool
+}
+
+var (
+	structFieldsCache sync.Map
+	valuerType        = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// ToMap takes a struct, or a pointer to a struct, and returns a Map of its fields, using the "db" tag as
+// column name, or the lower case field name if it's undefined.
+// Fields tagged with "-" are ignored, so are fields tagged with "omitempty" option if they have a zero value.
+// Embedded structs fields are promoted, whereas named struct fields are ignored unless they implement driver.Valuer.
+func ToMap(arg interface{}) types.Map {
+	value, ok := toStructValue(arg)
+	if !ok {
+		panic(types.NewErrorf(types.ErrInvalidPairs, "cannot use %T as struct", arg))
+	}
+
+	fields := getStructFields(value.Type())
+	values := make(types.Map, len(fields))
+
+	for i := range fields {
+		if fields[i].Nested {
+			continue
+		}
+
+		field, ok := fieldByIndex(value, fields[i].Index)
+		if !ok || (fields[i].OmitEmpty && field.IsZero()) {
+			continue
+		}
+
+		values[fields[i].Column] = toFieldValue(field)
+	}
+
+	return values
+}
+
+// isStruct returns true if given value is a struct, or a non-nil pointer to a struct.
+func isStruct(arg interface{}) bool {
+	_, ok := toStructValue(arg)
+	return ok
+}
+
+func toStructValue(arg interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(arg)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	return value, value.Kind() == reflect.Struct && !isLeafType(value.Type())
+}
+
+// getStructFields returns the fields of given struct type, which are computed once per type.
+func getStructFields(kind reflect.Type) []structField {
+	cached, ok := structFieldsCache.Load(kind)
+	if ok {
+		return cached.([]structField)
+	}
+
+	fields := appendStructFields(nil, kind, nil, "", false, nil)
+	structFieldsCache.Store(kind, fields)
+
+	return fields
+}
+
+// StructIndexes returns the index sequence of the fields of given struct type, by column name.
+// Columns of nested structs use their prefixed name, such as "author.id", as aliased by StructColumns.
+func StructIndexes(kind reflect.Type) map[string][]int {
+	fields := getStructFields(kind)
+	indexes := make(map[string][]int, len(fields))
+	for i := range fields {
+		indexes[fields[i].Column] = fields[i].Index
+	}
+	return indexes
+}
+
+// appendStructFields appends the fields of given struct type, and of its embedded and nested structs.
+// Parents are the struct types being traversed: a nested struct whose type is one of them, such as a model
+// referencing itself, is ignored to prevent an infinite recursion.
+func appendStructFields(fields []structField, kind reflect.Type, index []int, prefix string,
+	nested bool, parents []reflect.Type) []structField {
+
+	parents = append(parents[:len(parents):len(parents)], kind)
+
+	for i := 0; i < kind.NumField(); i++ {
+		field := kind.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, options := parseTag(field.Tag.Get("db"))
+		if name == "-" {
+			continue
+		}
+
+		path := make([]int, 0, len(index)+1)
+		path = append(path, index...)
+		path = append(path, i)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && !isLeafType(fieldType) {
+			if hasType(parents, fieldType) {
+				continue
+			}
+			if field.Anonymous && name == "" {
+				fields = appendStructFields(fields, fieldType, path, prefix, nested, parents)
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			fields = appendStructFields(fields, fieldType, path, prefix+name+".", true, parents)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields = append(fields, structField{
+			Column:    prefix + name,
+			Index:     path,
+			OmitEmpty: strings.Contains(","+options+",", ",omitempty,"),
+			Nested:    nested,
+		})
+	}
+
+	return fields
+}
+
+func hasType(list []reflect.Type, kind reflect.Type) bool {
+	for i := range list {
+		if list[i] == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// isLeafType returns true if given struct type is a single value, such as time.Time or sql.NullString.
+func isLeafType(kind reflect.Type) bool {
+	return kind == timeType || kind.Implements(valuerType) || reflect.PtrTo(kind).Implements(valuerType)
+}
+
+func parseTag(tag string) (string, string) {
+	i := strings.Index(tag, ",")
+	if i < 0 {
+		return tag, ""
+	}
+	return tag[:i], tag[i+1:]
+}
+
+// fieldByIndex returns the field of given struct with given index sequence,
+// or false if it belongs to a nil embedded struct.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+	return value, true
+}
+
+// toFieldValue returns the value of given field, dereferencing pointers that don't implement driver.Valuer.
+func toFieldValue(field reflect.Value) interface{} {
+	if field.Kind() == reflect.Ptr && field.IsNil() {
+		return nil
+	}
+	value := field.Interface()
+	if _, ok := value.(driver.Valuer); ok || field.Kind() != reflect.Ptr {
+		return value
+	}
+	return field.Elem().Interface()
+}
+
+// StructColumns generates the columns of a struct, using the "db" tag of its fields.
+// Fields of nested structs are aliased with their prefixed name, such as "author.id", as expected by sqlx.
+type StructColumns struct {
+	model   interface{}
+	table   string
+	tables  map[string]string
+	exclude []string
+}
+
+// NewStructColumns creates a new StructColumns using given struct, or pointer to a struct.
+func NewStructColumns(model interface{}) StructColumns {
+	return StructColumns{
+		model: model,
+	}
+}
+
+// Table defines the table, or table alias, used as prefix of the columns.
+func (columns StructColumns) Table(table string) StructColumns {
+	columns.table = table
+	return columns
+}
+
+// Join defines the table, or table alias, used as prefix of the columns of a nested struct, identified by its name.
+// If undefined, the last part of the nested struct name is used.
+func (columns StructColumns) Join(name string, table string) StructColumns {
+	tables := make(map[string]string, len(columns.tables)+1)
+	for key, value := range columns.tables {
+		tables[key] = value
+	}
+	tables[name] = table
+	columns.tables = tables
+	return columns
+}
+
+// Without excludes given columns, using their name or their prefixed name for nested structs.
+func (columns StructColumns) Without(names ...string) StructColumns {
+	exclude := make([]string, 0, len(columns.exclude)+len(names))
+	exclude = append(exclude, columns.exclude...)
+	columns.exclude = append(exclude, names...)
+	return columns
+}
+
+// Columns returns the columns of the struct.
+func (columns StructColumns) Columns() []stmt.Column {
+	value, ok := toStructValue(columns.model)
+	if !ok {
+		panic(types.NewErrorf(types.ErrInvalidColumn, "cannot use %T as struct", columns.model))
+	}
+
+	fields := getStructFields(value.Type())
+	list := make([]stmt.Column, 0, len(fields))
+
+	for i := range fields {
+		if columns.isExcluded(fields[i].Column) {
+			continue
+		}
+		if !fields[i].Nested {
+			list = append(list, stmt.NewColumn(columns.prefix(columns.table, fields[i].Column)))
+			continue
+		}
+
+		dot := strings.LastIndex(fields[i].Column, ".")
+		table := columns.joinTable(fields[i].Column[:dot])
+		name := columns.prefix(table, fields[i].Column[dot+1:])
+		list = append(list, stmt.NewColumnAlias(name, fields[i].Column))
+	}
+
+	return list
+}
+
+func (columns StructColumns) isExcluded(column string) bool {
+	for i := range columns.exclude {
+		if columns.exclude[i] == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (columns StructColumns) joinTable(name string) string {
+	table, ok := columns.tables[name]
+	if ok {
+		return table
+	}
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func (StructColumns) prefix(table string, column string) string {
+	if table == "" {
+		return column
+	}
+	return table + "." + column
+}
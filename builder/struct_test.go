@@ -0,0 +1,290 @@
+package builder_test
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/builder"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+type Timestamps struct {
+	CreatedAt time.Time   `db:"created_at"`
+	DeletedAt pq.NullTime `db:"deleted_at"`
+}
+
+type Author struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+type Comment struct {
+	Timestamps
+	ID       int64          `db:"id,omitempty"`
+	Email    string         `db:"email"`
+	Status   string         `db:"status,omitempty"`
+	Reply    *string        `db:"reply"`
+	Nickname sql.NullString `db:"nickname"`
+	UserID   int64
+	Author   Author `db:"author"`
+	Internal string `db:"-"`
+	secret   string
+}
+
+type Category struct {
+	ID       int64     `db:"id"`
+	Name     string    `db:"name"`
+	Parent   *Category `db:"parent"`
+	Featured *Feature  `db:"featured"`
+}
+
+type Feature struct {
+	ID       int64     `db:"id"`
+	Category *Category `db:"category"`
+}
+
+func TestToMap(t *testing.T) {
+	is := require.New(t)
+
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	reply := "thanks"
+	comment := Comment{
+		Timestamps: Timestamps{CreatedAt: when},
+		Email:      "tech@ulule.com",
+		Reply:      &reply,
+		UserID:     42,
+		Author:     Author{ID: 1, Name: "ulule"},
+		Internal:   "internal",
+		secret:     "secret",
+	}
+
+	// Tags, embedded structs, pointers and driver.Valuer fields are supported...
+	{
+		expected := types.Map{
+			"created_at": when,
+			"deleted_at": pq.NullTime{},
+			"email":      "tech@ulule.com",
+			"reply":      "thanks",
+			"nickname":   sql.NullString{},
+			"userid":     int64(42),
+		}
+		is.Equal(expected, builder.ToMap(comment))
+		is.Equal(expected, builder.ToMap(&comment))
+	}
+
+	// ...omitempty fields are ignored unless they're defined...
+	{
+		comment := comment
+		comment.ID = 2
+		comment.Status = "published"
+		comment.Reply = nil
+
+		values := builder.ToMap(comment)
+		is.Len(values, 8)
+		is.Equal(int64(2), values["id"])
+		is.Equal("published", values["status"])
+		is.Nil(values["reply"])
+	}
+
+	// ...and the map can be restricted.
+	{
+		is.Equal(types.Map{"email": "tech@ulule.com"}, loukoum.StructMap(comment).Only("email", "id"))
+		is.Len(loukoum.StructMap(comment).Without("created_at", "deleted_at"), 4)
+	}
+
+	// Corner cases...
+	{
+		is.Panics(func() {
+			builder.ToMap(42)
+		})
+	}
+	{
+		is.Panics(func() {
+			builder.ToMap((*Comment)(nil))
+		})
+	}
+	{
+		is.Panics(func() {
+			builder.ToMap(time.Now())
+		})
+	}
+}
+
+func TestStruct_Set(t *testing.T) {
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	author := Author{ID: 1, Name: "ulule"}
+
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Insert",
+			Builders: []builder.Builder{
+				loukoum.Insert("authors").Set(author),
+				loukoum.Insert("authors").Set(&author),
+				loukoum.Insert("authors").Set(loukoum.StructMap(author)),
+			},
+			String:     "INSERT INTO authors (id, name) VALUES (1, 'ulule')",
+			Query:      "INSERT INTO authors (id, name) VALUES ($1, $2)",
+			NamedQuery: "INSERT INTO authors (id, name) VALUES (:arg_1, :arg_2)",
+			Args:       []interface{}{int64(1), "ulule"},
+		},
+		{
+			Name: "Multiple rows",
+			Builder: loukoum.
+				Insert("authors").
+				Set(author).
+				Set(Author{ID: 2, Name: "loukoum"}),
+			String:     "INSERT INTO authors (id, name) VALUES (1, 'ulule'), (2, 'loukoum')",
+			Query:      "INSERT INTO authors (id, name) VALUES ($1, $2), ($3, $4)",
+			NamedQuery: "INSERT INTO authors (id, name) VALUES (:arg_1, :arg_2), (:arg_3, :arg_4)",
+			Args:       []interface{}{int64(1), "ulule", int64(2), "loukoum"},
+		},
+		{
+			Name: "Update",
+			Builder: loukoum.
+				Update("comments").
+				Set(loukoum.StructMap(Comment{Timestamps: Timestamps{CreatedAt: when}}).Only("created_at", "deleted_at")).
+				Where(loukoum.Condition("id").Equal(1)),
+			String: fmt.Sprint(
+				"UPDATE comments SET created_at = '2020-01-02 03:04:05+00', deleted_at = NULL WHERE (id = 1)",
+			),
+			Query:      "UPDATE comments SET created_at = $1, deleted_at = $2 WHERE (id = $3)",
+			NamedQuery: "UPDATE comments SET created_at = :arg_1, deleted_at = :arg_2 WHERE (id = :arg_3)",
+			Args:       []interface{}{when, pq.NullTime{}, 1},
+		},
+		{
+			Name: "Upsert",
+			Builder: loukoum.
+				Insert("authors").
+				Set(author).
+				OnConflict("id", loukoum.DoUpdate(loukoum.StructMap(author).Without("id"))),
+			String:     "INSERT INTO authors (id, name) VALUES (1, 'ulule') ON CONFLICT (id) DO UPDATE SET name = 'ulule'",
+			Query:      "INSERT INTO authors (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = $3",
+			NamedQuery: "INSERT INTO authors (id, name) VALUES (:arg_1, :arg_2) ON CONFLICT (id) DO UPDATE SET name = :arg_3",
+			Args:       []interface{}{int64(1), "ulule", "ulule"},
+		},
+		{
+			Name: "Invalid struct",
+			Failure: func() builder.Builder {
+				return loukoum.Insert("authors").Set([]Author{author})
+			},
+		},
+	})
+}
+
+func TestStructColumns(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Simple",
+			Builders: []builder.Builder{
+				loukoum.Select(loukoum.StructColumns(Author{})).From("authors"),
+				loukoum.Select(loukoum.StructColumns(&Author{})).From("authors"),
+			},
+			SameQuery: "SELECT id, name FROM authors",
+		},
+		{
+			Name: "Table",
+			Builder: loukoum.
+				Select(loukoum.StructColumns(Author{}).Table("a"), loukoum.Count("*").As("comments")).
+				From(loukoum.Table("authors").As("a")),
+			SameQuery: "SELECT a.id, a.name, COUNT(*) AS comments FROM authors AS a",
+		},
+		{
+			Name: "Nested",
+			Builder: loukoum.
+				Select(loukoum.StructColumns(Comment{}).Table("c").Join("author", "u").Without("deleted_at", "author.name")).
+				From(loukoum.Table("comments").As("c")).
+				Join(loukoum.Table("users").As("u"), loukoum.On("c.userid", "u.id")),
+			SameQuery: fmt.Sprint(
+				`SELECT c.created_at, c.id, c.email, c.status, c.reply, c.nickname, c.userid, u.id AS "author.id" `,
+				"FROM comments AS c INNER JOIN users AS u ON c.userid = u.id",
+			),
+		},
+		{
+			Name: "Nested without join",
+			Builder: loukoum.
+				Select(loukoum.StructColumns(Comment{}).Without("created_at", "deleted_at", "reply", "nickname")).
+				From("comments"),
+			SameQuery: fmt.Sprint(
+				`SELECT id, email, status, userid, author.id AS "author.id", author.name AS "author.name" `,
+				"FROM comments",
+			),
+		},
+		{
+			Name: "MySQL",
+			Builder: loukoum.
+				Select(loukoum.StructColumns(Comment{}).Table("c").Join("author", "u").Without("deleted_at", "author.name")).
+				From(loukoum.Table("comments").As("c")).
+				Dialect(loukoum.MySQL),
+			SameQuery: fmt.Sprint(
+				"SELECT `c`.`created_at`, `c`.`id`, `c`.`email`, `c`.`status`, `c`.`reply`, `c`.`nickname`, ",
+				"`c`.`userid`, `u`.`id` AS `author.id` FROM `comments` AS `c`",
+			),
+		},
+		{
+			Name: "Strict",
+			Builder: loukoum.
+				Select(loukoum.StructColumns(Comment{}).Table("c").Join("author", "u").Without("deleted_at", "author.name")).
+				From(loukoum.Table("comments").As("c")).
+				Dialect(types.PostgreSQLDialect{Strict: true}),
+			SameQuery: fmt.Sprint(
+				`SELECT c.created_at, c.id, c.email, c.status, c.reply, c.nickname, c.userid, u.id AS "author.id" `,
+				"FROM comments AS c",
+			),
+		},
+		{
+			Name: "SQLite",
+			Builder: loukoum.
+				Select(loukoum.StructColumns(Comment{}).Without("created_at", "deleted_at", "reply", "nickname")).
+				From("comments").
+				Dialect(loukoum.SQLite),
+			SameQuery: fmt.Sprint(
+				`SELECT id, email, status, userid, author.id AS "author.id", author.name AS "author.name" `,
+				"FROM comments",
+			),
+		},
+		{
+			Name: "Invalid struct",
+			Failure: func() builder.Builder {
+				return loukoum.Select(loukoum.StructColumns(42)).From("authors")
+			},
+		},
+	})
+}
+
+func TestStruct_SelfReference(t *testing.T) {
+	is := require.New(t)
+
+	// Nested structs referencing a parent struct are ignored, instead of being traversed forever...
+	category := Category{ID: 2, Name: "music", Parent: &Category{ID: 1, Name: "art"}}
+	{
+		is.Equal(types.Map{"id": int64(2), "name": "music"}, builder.ToMap(category))
+
+		query, args := loukoum.Insert("categories").Set(category).Query()
+		is.Equal("INSERT INTO categories (id, name) VALUES ($1, $2)", query)
+		is.Equal([]interface{}{int64(2), "music"}, args)
+
+		query, _ = loukoum.Select(loukoum.StructColumns(category)).From("categories").Query()
+		is.Equal(`SELECT id, name, featured.id AS "featured.id" FROM categories`, query)
+
+		values, err := loukoum.Bind([]interface{}{types.Param{Name: "name"}}, category)
+		is.NoError(err)
+		is.Equal([]interface{}{"music"}, values)
+	}
+
+	// ...whereas other nested structs are still mapped.
+	{
+		indexes := builder.StructIndexes(reflect.TypeOf(Feature{}))
+		is.Equal(map[string][]int{
+			"id":            {0},
+			"category.id":   {1, 0},
+			"category.name": {1, 1},
+		}, indexes)
+	}
+}
@@ -0,0 +1,72 @@
+package builder
+
+// Template is a query compiled once, which stores its statement and the layout of its arguments.
+// Values of its named parameters are given on each execution, without generating the query again.
+// A Template is immutable and safe for concurrent use.
+type Template struct {
+	query      string
+	args       []interface{}
+	namedQuery string
+	namedArgs  map[string]interface{}
+}
+
+// Compile generates the regular and named statements of given builder, whose named parameters are bound on
+// each execution. Other arguments are captured at compilation.
+func Compile(builder Builder) (Template, error) {
+	query, args, err := builder.Build()
+	if err != nil {
+		return Template{}, err
+	}
+
+	namedQuery, namedArgs, err := builder.BuildNamed()
+	if err != nil {
+		return Template{}, err
+	}
+
+	return Template{
+		query:      query,
+		args:       args,
+		namedQuery: namedQuery,
+		namedArgs:  namedArgs,
+	}, nil
+}
+
+// NamedQuery returns the named statement, with its arguments bound using given values.
+// It panics if a parameter is missing or unknown: use BuildNamed() to handle errors.
+func (t Template) NamedQuery(values interface{}) (string, map[string]interface{}) {
+	query, args, err := t.BuildNamed(values)
+	if err != nil {
+		panic(err)
+	}
+	return query, args
+}
+
+// Query returns the regular statement, with its arguments bound using given values.
+// It panics if a parameter is missing or unknown: use Build() to handle errors.
+func (t Template) Query(values interface{}) (string, []interface{}) {
+	query, args, err := t.Build(values)
+	if err != nil {
+		panic(err)
+	}
+	return query, args
+}
+
+// BuildNamed returns the named statement, with its arguments bound using given values, which is a map or a
+// struct, or every missing and unknown parameter.
+func (t Template) BuildNamed(values interface{}) (string, map[string]interface{}, error) {
+	args, err := BindNamed(t.namedArgs, values)
+	if err != nil {
+		return "", nil, err
+	}
+	return t.namedQuery, args, nil
+}
+
+// Build returns the regular statement, with its arguments bound using given values, which is a map or a
+// struct, or every missing and unknown parameter.
+func (t Template) Build(values interface{}) (string, []interface{}, error) {
+	args, err := Bind(t.args, values)
+	if err != nil {
+		return "", nil, err
+	}
+	return t.query, args, nil
+}
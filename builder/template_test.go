@@ -0,0 +1,71 @@
+package builder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3"
+)
+
+func TestTemplate(t *testing.T) {
+	is := require.New(t)
+
+	template, err := loukoum.Compile(loukoum.Select("id", "email").
+		From("users").
+		Where(loukoum.Condition("id").Equal(loukoum.Param("user_id"))).
+		And(loukoum.Condition("status").Equal("active")))
+	is.NoError(err)
+
+	// Templates bind their parameters on each execution, and keep other arguments...
+	{
+		query, args, err := template.Build(loukoum.Map{"user_id": 1})
+		is.NoError(err)
+		is.Equal("SELECT id, email FROM users WHERE ((id = $1) AND (status = $2))", query)
+		is.Equal([]interface{}{1, "active"}, args)
+
+		query, args = template.Query(map[string]interface{}{"user_id": 2})
+		is.Equal("SELECT id, email FROM users WHERE ((id = $1) AND (status = $2))", query)
+		is.Equal([]interface{}{2, "active"}, args)
+	}
+	{
+		query, args, err := template.BuildNamed(map[string]interface{}{"user_id": 1})
+		is.NoError(err)
+		is.Equal("SELECT id, email FROM users WHERE ((id = :user_id) AND (status = :arg_1))", query)
+		is.Equal(map[string]interface{}{"user_id": 1, "arg_1": "active"}, args)
+
+		query, args = template.NamedQuery(map[string]interface{}{"user_id": 2})
+		is.Equal("SELECT id, email FROM users WHERE ((id = :user_id) AND (status = :arg_1))", query)
+		is.Equal(map[string]interface{}{"user_id": 2, "arg_1": "active"}, args)
+	}
+
+	// ...and report missing and unknown parameters.
+	{
+		query, args, err := template.Build(nil)
+		is.True(errors.Is(err, loukoum.ErrInvalidParameter))
+		is.Empty(query)
+		is.Nil(args)
+
+		is.Panics(func() {
+			template.NamedQuery(map[string]interface{}{"user_id": 1, "id": 1})
+		})
+	}
+
+	// Templates without parameters always return the same query.
+	{
+		template, err := loukoum.Compile(loukoum.Select("id").From("users"))
+		is.NoError(err)
+
+		query, args, err := template.Build(nil)
+		is.NoError(err)
+		is.Equal("SELECT id FROM users", query)
+		is.Nil(args)
+	}
+
+	// Invalid builders cannot be compiled.
+	{
+		_, err := loukoum.Compile(loukoum.Select("id").From("users").Limit(0))
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+	}
+}
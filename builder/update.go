@@ -7,14 +7,26 @@ import (
 
 // Update is a builder used for "UPDATE" query.
 type Update struct {
-	query stmt.Update
+	query   stmt.Update
+	dialect types.Dialect
+	errs    types.Errors
 }
 
 // NewUpdate creates a new Update.
 func NewUpdate(arg interface{}) Update {
-	return Update{
-		query: stmt.NewUpdate(ToTable(arg)),
+	b := Update{
+		query: stmt.NewUpdate(stmt.Table{}),
 	}
+	return b.table(arg)
+}
+
+// table sets the table of the query.
+func (b Update) table(arg interface{}) (next Update) {
+	defer b.catch(&next)
+
+	b.query.Table = ToTable(arg)
+
+	return b
 }
 
 // Only sets the ONLY clause.
@@ -24,9 +36,11 @@ func (b Update) Only() Update {
 }
 
 // Set adds a SET clause.
-func (b Update) Set(args ...interface{}) Update {
+func (b Update) Set(args ...interface{}) (next Update) {
+	defer b.catch(&next)
+
 	if len(args) == 0 {
-		panic("loukoum: update set clause requires at least one argument")
+		panic(types.NewError(types.ErrInvalidPairs, "update set clause requires at least one argument"))
 	}
 
 	b.query.Set = MergeSet(b.query.Set, args)
@@ -35,13 +49,15 @@ func (b Update) Set(args ...interface{}) Update {
 
 // Using assigns the result of the given expression to
 // the columns defined in Set.
-func (b Update) Using(args ...interface{}) Update {
+func (b Update) Using(args ...interface{}) (next Update) {
+	defer b.catch(&next)
+
 	if b.query.Set.Pairs.Mode != stmt.PairArrayMode {
-		panic("loukoum: you can only use Using with column-list syntax")
+		panic(types.NewError(types.ErrInvalidPairs, "you can only use Using with column-list syntax"))
 	}
 
 	if len(args) == 0 {
-		panic("loukoum: using clause requires a column or an expression")
+		panic(types.NewError(types.ErrInvalidPairs, "using clause requires a column or an expression"))
 	}
 
 	for i := range args {
@@ -63,7 +79,9 @@ func (b Update) With(args ...stmt.WithQuery) Update {
 }
 
 // Where adds WHERE clauses.
-func (b Update) Where(condition stmt.Expression) Update {
+func (b Update) Where(condition stmt.Expression) (next Update) {
+	defer b.catch(&next)
+
 	if b.query.Where.IsEmpty() {
 		b.query.Where = stmt.NewWhere(condition)
 		return b
@@ -73,21 +91,27 @@ func (b Update) Where(condition stmt.Expression) Update {
 }
 
 // And adds AND WHERE conditions.
-func (b Update) And(condition stmt.Expression) Update {
+func (b Update) And(condition stmt.Expression) (next Update) {
+	defer b.catch(&next)
+
 	b.query.Where = b.query.Where.And(condition)
 	return b
 }
 
 // Or adds OR WHERE conditions.
-func (b Update) Or(condition stmt.Expression) Update {
+func (b Update) Or(condition stmt.Expression) (next Update) {
+	defer b.catch(&next)
+
 	b.query.Where = b.query.Where.Or(condition)
 	return b
 }
 
 // From sets the FROM clause of the query.
-func (b Update) From(arg interface{}) Update {
+func (b Update) From(arg interface{}) (next Update) {
+	defer b.catch(&next)
+
 	if !b.query.From.IsEmpty() {
-		panic("loukoum: update builder has from clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "update builder has from clause already defined"))
 	}
 
 	b.query.From = ToFrom(arg)
@@ -96,43 +120,101 @@ func (b Update) From(arg interface{}) Update {
 }
 
 // Returning adds a RETURNING clause.
-func (b Update) Returning(values ...interface{}) Update {
+func (b Update) Returning(values ...interface{}) (next Update) {
+	defer b.catch(&next)
+
 	if !b.query.Returning.IsEmpty() {
-		panic("loukoum: update builder has returning clause already defined")
+		panic(types.NewError(types.ErrDuplicateClause, "update builder has returning clause already defined"))
 	}
 
-	b.query.Returning = stmt.NewReturning(ToColumns(values))
+	b.query.Returning = ToReturning(values)
 
 	return b
 }
 
+// Dialect defines the dialect used to generate the query.
+func (b Update) Dialect(dialect types.Dialect) Update {
+	b.dialect = dialect
+	return b
+}
+
 // String returns the underlying query as a raw statement.
 // This function should be used for debugging since it doesn't escape anything and is completely
 // vulnerable to SQL injection.
 // You should use either NamedQuery() or Query()...
+// If the query is invalid, its errors are returned instead.
 func (b Update) String() string {
-	ctx := &types.RawContext{}
-	b.query.Write(ctx)
-	return ctx.Query()
+	return toString(b.dialect, b.query, b.errs)
 }
 
 // NamedQuery returns the underlying query as a named statement.
+// It panics if the query is invalid: use BuildNamed() to handle errors.
 func (b Update) NamedQuery() (string, map[string]interface{}) {
-	ctx := &types.NamedContext{}
-	b.query.Write(ctx)
-	return ctx.Query(), ctx.Values()
+	return mustBuildNamed(b.BuildNamed())
 }
 
 // Query returns the underlying query as a regular statement.
+// It panics if the query is invalid: use Build() to handle errors.
 func (b Update) Query() (string, []interface{}) {
-	ctx := &types.StdContext{}
-	b.query.Write(ctx)
-	return ctx.Query(), ctx.Values()
+	return mustBuild(b.Build())
+}
+
+// BuildNamed returns the underlying query as a named statement,
+// or every error found while building and writing the query.
+func (b Update) BuildNamed() (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, types.IndexNaming)
+}
+
+// Build returns the underlying query as a regular statement,
+// or every error found while building and writing the query.
+func (b Update) Build() (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, types.PlaceholderStyle{})
+}
+
+// NamedQueryWith returns the underlying query as a named statement, using given naming style for its arguments.
+// It panics if the query is invalid: use BuildNamedWith() to handle errors.
+func (b Update) NamedQueryWith(style types.NamingStyle) (string, map[string]interface{}) {
+	return mustBuildNamed(b.BuildNamedWith(style))
+}
+
+// BuildNamedWith returns the underlying query as a named statement, using given naming style for its arguments,
+// or every error found while building and writing the query.
+func (b Update) BuildNamedWith(style types.NamingStyle) (string, map[string]interface{}, error) {
+	return buildNamed(b.dialect, b.query, b.errs, style)
+}
+
+// QueryWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect.
+// It panics if the query is invalid: use BuildWith() to handle errors.
+func (b Update) QueryWith(style types.PlaceholderStyle) (string, []interface{}) {
+	return mustBuild(b.BuildWith(style))
+}
+
+// BuildWith returns the underlying query as a regular statement, using given placeholder style instead of
+// the placeholders of the dialect, or every error found while building and writing the query.
+func (b Update) BuildWith(style types.PlaceholderStyle) (string, []interface{}, error) {
+	return build(b.dialect, b.query, b.errs, style)
 }
 
-// Statement returns underlying statement.
+// Statement returns underlying statement, which reports errors of the builder when it's written.
 func (b Update) Statement() stmt.Statement {
-	return b.query
+	query := b.query
+	query.Errors = b.errs
+	return query
+}
+
+// Err returns errors found while building the query, or nil if every clause is valid.
+func (b Update) Err() error {
+	return b.errs.Err()
+}
+
+// catch records an error raised by a builder method and returns the builder as it was before the call.
+func (b Update) catch(next *Update) {
+	err := recoverError(recover())
+	if err != nil {
+		b.errs = types.AppendError(b.errs, err)
+		*next = b
+	}
 }
 
 // Ensure that Update is a Builder
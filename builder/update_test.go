@@ -454,6 +454,95 @@ func TestUpdate_Set_Subquery(t *testing.T) {
 	})
 }
 
+func TestUpdate_Set_Arithmetic(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Subtract",
+			Builder: loukoum.Update("products").
+				Set(loukoum.Pair("stock", loukoum.Condition("stock").Subtract(3))).
+				Where(loukoum.Condition("id").Equal(1)),
+			String:     "UPDATE products SET stock = (stock - 3) WHERE (id = 1)",
+			Query:      "UPDATE products SET stock = (stock - $1) WHERE (id = $2)",
+			NamedQuery: "UPDATE products SET stock = (stock - :arg_1) WHERE (id = :arg_2)",
+			Args:       []interface{}{3, 1},
+		},
+		{
+			Name: "Nested",
+			Builder: loukoum.Update("products").
+				Set(loukoum.Pair("price", loukoum.Condition("price").Multiply(2).Add(loukoum.Condition("fee")))),
+			String:     "UPDATE products SET price = ((price * 2) + fee)",
+			Query:      "UPDATE products SET price = ((price * $1) + fee)",
+			NamedQuery: "UPDATE products SET price = ((price * :arg_1) + fee)",
+			Args:       []interface{}{2},
+		},
+		{
+			Name: "Concat MySQL",
+			Builder: loukoum.Update("users").
+				Set(loukoum.Pair("name", loukoum.Condition("first_name").Concat(" ").Concat(loukoum.Condition("last_name")))).
+				Dialect(loukoum.MySQL),
+			String:     "UPDATE `users` SET `name` = CONCAT(CONCAT(`first_name`, ' '), `last_name`)",
+			Query:      "UPDATE `users` SET `name` = CONCAT(CONCAT(`first_name`, ?), `last_name`)",
+			NamedQuery: "UPDATE `users` SET `name` = CONCAT(CONCAT(`first_name`, :arg_1), `last_name`)",
+			Args:       []interface{}{" "},
+		},
+	})
+}
+
+func TestUpdate_Set_Func(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Set and returning",
+			Builder: loukoum.Update("users").
+				Set(
+					loukoum.Pair("email", loukoum.Func("lower", "Tom@Ulule.com")),
+					loukoum.Pair("updated_at", loukoum.Func("now")),
+				).
+				Where(loukoum.Condition("id").Equal(1)).
+				Returning("id", loukoum.Func("upper", loukoum.Column("email")).As("upper_email")),
+			String: fmt.Sprint(
+				"UPDATE users SET email = lower('Tom@Ulule.com'), updated_at = now() WHERE (id = 1) ",
+				"RETURNING id, upper(email) AS upper_email",
+			),
+			Query: fmt.Sprint(
+				"UPDATE users SET email = lower($1), updated_at = now() WHERE (id = $2) ",
+				"RETURNING id, upper(email) AS upper_email",
+			),
+			NamedQuery: fmt.Sprint(
+				"UPDATE users SET email = lower(:arg_1), updated_at = now() WHERE (id = :arg_2) ",
+				"RETURNING id, upper(email) AS upper_email",
+			),
+			Args: []interface{}{"Tom@Ulule.com", 1},
+		},
+	})
+}
+
+func TestUpdate_Set_Case(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Priority",
+			Builder: loukoum.Update("tickets").
+				Set(loukoum.Pair("priority", loukoum.Case().
+					When(loukoum.Condition("severity").Equal("critical"), 1).
+					Else(loukoum.Column("priority")),
+				)).
+				Where(loukoum.Condition("closed").Equal(false)),
+			String: fmt.Sprint(
+				"UPDATE tickets SET priority = CASE WHEN (severity = 'critical') THEN 1 ELSE priority END ",
+				"WHERE (closed = false)",
+			),
+			Query: fmt.Sprint(
+				"UPDATE tickets SET priority = CASE WHEN (severity = $1) THEN $2 ELSE priority END ",
+				"WHERE (closed = $3)",
+			),
+			NamedQuery: fmt.Sprint(
+				"UPDATE tickets SET priority = CASE WHEN (severity = :arg_1) THEN :arg_2 ELSE priority END ",
+				"WHERE (closed = :arg_3)",
+			),
+			Args: []interface{}{"critical", 1, false},
+		},
+	})
+}
+
 func TestUpdate_Set_Using(t *testing.T) {
 	RunBuilderTests(t, []BuilderTest{
 		{
@@ -671,3 +760,44 @@ func TestUpdate_With(t *testing.T) {
 		},
 	})
 }
+
+func TestUpdate_MySQL(t *testing.T) {
+	RunBuilderTests(t, []BuilderTest{
+		{
+			Name: "Simple",
+			Builder: loukoum.
+				Update("table").
+				Set(loukoum.Pair("a", 1), loukoum.Pair("b", 2)).
+				Where(loukoum.Condition("id").Equal(3)).
+				Dialect(loukoum.MySQL),
+			String:     "UPDATE `table` SET `a` = 1, `b` = 2 WHERE (`id` = 3)",
+			Query:      "UPDATE `table` SET `a` = ?, `b` = ? WHERE (`id` = ?)",
+			NamedQuery: "UPDATE `table` SET `a` = :arg_1, `b` = :arg_2 WHERE (`id` = :arg_3)",
+			Args:       []interface{}{1, 2, 3},
+		},
+		{
+			Name: "Only",
+			Failure: func() builder.Builder {
+				return loukoum.Update("table").Only().Set(loukoum.Pair("a", 1)).Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "From",
+			Failure: func() builder.Builder {
+				return loukoum.Update("table").Set(loukoum.Pair("a", 1)).From("test").Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "Column-list",
+			Failure: func() builder.Builder {
+				return loukoum.Update("table").Set("a", "b").Using(1, 2).Dialect(loukoum.MySQL)
+			},
+		},
+		{
+			Name: "Returning",
+			Failure: func() builder.Builder {
+				return loukoum.Update("table").Set(loukoum.Pair("a", 1)).Returning("id").Dialect(loukoum.MySQL)
+			},
+		},
+	})
+}
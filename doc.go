@@ -1,5 +1,7 @@
 // Package loukoum provides a simple SQL Query Builder.
-// At the moment, only PostgreSQL is supported.
+// PostgreSQL is the default dialect, but MySQL and SQLite are also supported using Dialect():
+//
+//   builder := loukoum.Select("id").From("users").Dialect(loukoum.MySQL)
 //
 // If you have to generate complex queries, which rely on various contexts, loukoum is the right tool for you.
 // It helps you generate SQL queries from composable parts.
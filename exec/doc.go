@@ -0,0 +1,7 @@
+// Package exec executes queries generated by the "builder" package using database/sql.
+//
+// It's optional: loukoum remains usable with any SQL connector, but this package removes the boilerplate
+// required to execute a builder and scan its rows into structs or slices.
+//
+// Errors returned by the database are wrapped with the statement that was executed.
+package exec
@@ -0,0 +1,120 @@
+package exec_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// fakeDriver is a database/sql driver returning predefined rows, which records executed statements.
+type fakeDriver struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+	queries []string
+	args    [][]driver.Value
+}
+
+func openFake(driver *fakeDriver) *sql.DB {
+	return sql.OpenDB(fakeConnector{driver: driver})
+}
+
+type fakeConnector struct {
+	driver *fakeDriver
+}
+
+func (connector fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn{driver: connector.driver}, nil
+}
+
+func (connector fakeConnector) Driver() driver.Driver {
+	return connector.driver
+}
+
+func (*fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake: use a connector")
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (conn fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{driver: conn.driver, query: query}, nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error {
+	return nil
+}
+
+func (fakeTx) Rollback() error {
+	return nil
+}
+
+type fakeStmt struct {
+	driver *fakeDriver
+	query  string
+}
+
+func (fakeStmt) Close() error {
+	return nil
+}
+
+func (fakeStmt) NumInput() int {
+	return -1
+}
+
+func (stmt fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	stmt.record(args)
+	if stmt.driver.err != nil {
+		return nil, stmt.driver.err
+	}
+	return driver.RowsAffected(len(stmt.driver.rows)), nil
+}
+
+func (stmt fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	stmt.record(args)
+	if stmt.driver.err != nil {
+		return nil, stmt.driver.err
+	}
+	return &fakeRows{columns: stmt.driver.columns, rows: stmt.driver.rows}, nil
+}
+
+func (stmt fakeStmt) record(args []driver.Value) {
+	stmt.driver.queries = append(stmt.driver.queries, stmt.query)
+	stmt.driver.args = append(stmt.driver.args, args)
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (rows *fakeRows) Columns() []string {
+	return rows.columns
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+	return nil
+}
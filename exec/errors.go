@@ -0,0 +1,30 @@
+package exec
+
+import (
+	"errors"
+)
+
+// ErrInvalidDestination is returned when a destination cannot be used to scan rows.
+var ErrInvalidDestination = errors.New("invalid destination")
+
+// Error is an error returned while executing a statement, with the statement itself.
+type Error struct {
+	Query string
+	Err   error
+}
+
+func wrapError(query string, err error) error {
+	return &Error{
+		Query: query,
+		Err:   err,
+	}
+}
+
+func (e *Error) Error() string {
+	return "loukoum: " + e.Err.Error() + " (query: " + e.Query + ")"
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,248 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ulule/loukoum/v3/builder"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// DB is the interface used to execute queries, which is satisfied by *sql.DB, *sql.Tx and *sql.Conn.
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Executor executes builders on a database.
+type Executor struct {
+	db      DB
+	dialect types.Dialect
+	hooks   []Hook
+}
+
+// New creates a new Executor using given database.
+// Queries are generated with given dialect, or with the dialect of each builder if it's nil.
+func New(db DB, dialect types.Dialect) Executor {
+	return Executor{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// DB returns a copy of the executor using given database, such as a transaction.
+func (e Executor) DB(db DB) Executor {
+	e.db = db
+	return e
+}
+
+// Hooks returns a copy of the executor calling given hooks, in addition to its own, around every statement.
+func (e Executor) Hooks(hooks ...Hook) Executor {
+	list := make([]Hook, 0, len(e.hooks)+len(hooks))
+	list = append(list, e.hooks...)
+	e.hooks = append(list, hooks...)
+	return e
+}
+
+// Exec executes given builder without returning any rows.
+func (e Executor) Exec(ctx context.Context, b builder.Builder) (sql.Result, error) {
+	var result sql.Result
+	err := e.run(ctx, b, func(ctx context.Context, query string, args []interface{}) error {
+		var err error
+		result, err = e.db.ExecContext(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Query executes given builder and returns its rows.
+// Rows must be closed by the caller.
+func (e Executor) Query(ctx context.Context, b builder.Builder) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := e.run(ctx, b, func(ctx context.Context, query string, args []interface{}) error {
+		var err error
+		rows, err = e.db.QueryContext(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// QueryRow executes given builder, which is expected to return at most one row.
+// Errors are deferred until Row's Scan method is called. Errors found while scanning the row aren't
+// reported to hooks.
+func (e Executor) QueryRow(ctx context.Context, b builder.Builder) Row {
+	row := Row{}
+	row.err = e.run(ctx, b, func(ctx context.Context, query string, args []interface{}) error {
+		row.row = e.db.QueryRowContext(ctx, query, args...)
+		row.query = query
+		return row.row.Err()
+	})
+
+	return row
+}
+
+// Get executes given builder and scans its first row into dest, which is a pointer to a struct or to a single
+// value. Struct fields are matched with columns using their "db" tag, as in builder.ToMap().
+// It returns sql.ErrNoRows if the query doesn't return any rows.
+func (e Executor) Get(ctx context.Context, b builder.Builder, dest interface{}) error {
+	value, err := toDestination(dest)
+	if err != nil {
+		return err
+	}
+
+	return e.run(ctx, b, func(ctx context.Context, query string, args []interface{}) error {
+		rows, err := e.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		if !rows.Next() {
+			err = rows.Err()
+			if err != nil {
+				return err
+			}
+			return sql.ErrNoRows
+		}
+
+		err = scanRow(rows, value)
+		if err != nil {
+			return err
+		}
+
+		return rows.Close()
+	})
+}
+
+// Select executes given builder and scans its rows into dest, which is a pointer to a slice of structs,
+// of pointers to structs or of single values.
+// Struct fields are matched with columns using their "db" tag, as in builder.ToMap().
+func (e Executor) Select(ctx context.Context, b builder.Builder, dest interface{}) error {
+	slice, err := toSliceDestination(dest)
+	if err != nil {
+		return err
+	}
+
+	return e.run(ctx, b, func(ctx context.Context, query string, args []interface{}) error {
+		rows, err := e.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		return scanRows(rows, slice)
+	})
+}
+
+// run executes given function with the query of given builder, and calls hooks around it.
+// Errors returned by the function, except sql.ErrNoRows, are wrapped with the query.
+func (e Executor) run(ctx context.Context, b builder.Builder,
+	execute func(ctx context.Context, query string, args []interface{}) error) error {
+
+	query, args, err := e.build(b)
+	if err != nil {
+		return err
+	}
+
+	event := Event{
+		Kind:  toKind(b),
+		Query: query,
+		Args:  len(args),
+	}
+
+	for i := range e.hooks {
+		ctx = e.hooks[i].Before(ctx, event)
+	}
+
+	start := time.Now()
+	err = execute(ctx, query, args)
+	if err != nil && err != sql.ErrNoRows {
+		err = wrapError(query, err)
+	}
+
+	event.Duration = time.Since(start)
+	event.Err = err
+
+	for i := len(e.hooks) - 1; i >= 0; i-- {
+		e.hooks[i].After(ctx, event)
+	}
+
+	return err
+}
+
+// build returns the query of given builder, using the executor's dialect if it's defined.
+func (e Executor) build(b builder.Builder) (string, []interface{}, error) {
+	if e.dialect == nil {
+		return b.Build()
+	}
+
+	switch value := deref(b).(type) {
+	case builder.Select:
+		return value.Dialect(e.dialect).Build()
+	case builder.Insert:
+		return value.Dialect(e.dialect).Build()
+	case builder.Update:
+		return value.Dialect(e.dialect).Build()
+	case builder.Delete:
+		return value.Dialect(e.dialect).Build()
+	case builder.Compound:
+		return value.Dialect(e.dialect).Build()
+	default:
+		return b.Build()
+	}
+}
+
+// deref returns the builder pointed at by given builder, if it's a pointer, or given builder otherwise.
+func deref(b builder.Builder) builder.Builder {
+	switch value := b.(type) {
+	case *builder.Select:
+		return *value
+	case *builder.Insert:
+		return *value
+	case *builder.Update:
+		return *value
+	case *builder.Delete:
+		return *value
+	case *builder.Compound:
+		return *value
+	default:
+		return b
+	}
+}
+
+// Row is the result of Executor.QueryRow.
+type Row struct {
+	row   *sql.Row
+	query string
+	err   error
+}
+
+// Scan copies the columns of the row into the values pointed at by dest.
+// It returns sql.ErrNoRows if the query didn't return any rows.
+func (row Row) Scan(dest ...interface{}) error {
+	if row.err != nil {
+		return row.err
+	}
+
+	err := row.row.Scan(dest...)
+	if err != nil && err != sql.ErrNoRows {
+		return wrapError(row.query, err)
+	}
+
+	return err
+}
+
+// Err returns the error, if any, that was encountered while building or executing the query.
+func (row Row) Err() error {
+	return row.err
+}
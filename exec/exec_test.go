@@ -0,0 +1,295 @@
+package exec_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/exec"
+)
+
+type Author struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+type News struct {
+	ID          int64          `db:"id"`
+	Title       string         `db:"title"`
+	Summary     sql.NullString `db:"summary"`
+	PublishedAt *time.Time     `db:"published_at"`
+	Author      *Author        `db:"author"`
+}
+
+func TestExecutor_Exec(t *testing.T) {
+	is := require.New(t)
+	ctx := context.Background()
+
+	// Queries are generated with the executor's dialect...
+	{
+		fake := &fakeDriver{rows: [][]driver.Value{{}, {}}}
+		executor := exec.New(openFake(fake), loukoum.MySQL)
+
+		result, err := executor.Exec(ctx, loukoum.Update("news").
+			Set(loukoum.Pair("status", "published")).
+			Where(loukoum.Condition("id").In(1, 2)))
+		is.NoError(err)
+
+		count, err := result.RowsAffected()
+		is.NoError(err)
+		is.Equal(int64(2), count)
+		is.Equal([]string{"UPDATE `news` SET `status` = ? WHERE (`id` IN (?, ?))"}, fake.queries)
+		is.Equal([][]driver.Value{{"published", int64(1), int64(2)}}, fake.args)
+	}
+
+	{
+		fake := &fakeDriver{}
+		executor := exec.New(openFake(fake), loukoum.MySQL)
+
+		query := loukoum.Select("id").From("news").Where(loukoum.Condition("id").Equal(1))
+		_, err := executor.Exec(ctx, &query)
+		is.NoError(err)
+
+		union := query.Union(loukoum.Select("id").From("archives"))
+		_, err = executor.Exec(ctx, &union)
+		is.NoError(err)
+		is.Equal([]string{
+			"SELECT `id` FROM `news` WHERE (`id` = ?)",
+			"SELECT `id` FROM `news` WHERE (`id` = ?) UNION SELECT `id` FROM `archives`",
+		}, fake.queries)
+	}
+
+	// ...or with the builder's dialect if it's undefined.
+	{
+		fake := &fakeDriver{}
+		executor := exec.New(openFake(fake), nil)
+
+		_, err := executor.Exec(ctx, loukoum.Delete("news").
+			Where(loukoum.Condition("id").Equal(1)).
+			Dialect(loukoum.SQLite))
+		is.NoError(err)
+		is.Equal([]string{"DELETE FROM news WHERE (id = ?1)"}, fake.queries)
+	}
+
+	// Driver errors are wrapped with the statement...
+	{
+		fake := &fakeDriver{err: errors.New("fake: relation does not exist")}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		_, err := executor.Exec(ctx, loukoum.Delete("news").Where(loukoum.Condition("id").Equal(1)))
+		is.Error(err)
+		is.True(errors.Is(err, fake.err))
+		is.Equal("loukoum: fake: relation does not exist (query: DELETE FROM news WHERE (id = $1))", err.Error())
+
+		var execErr *exec.Error
+		is.True(errors.As(err, &execErr))
+		is.Equal("DELETE FROM news WHERE (id = $1)", execErr.Query)
+	}
+
+	// ...whereas invalid builders are never executed.
+	{
+		fake := &fakeDriver{}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		_, err := executor.Exec(ctx, loukoum.Select("id").From("news").Limit(-1))
+		is.Error(err)
+		is.True(errors.Is(err, loukoum.ErrInvalidLimit))
+		is.Empty(fake.queries)
+	}
+}
+
+func TestExecutor_QueryRow(t *testing.T) {
+	is := require.New(t)
+	ctx := context.Background()
+
+	{
+		fake := &fakeDriver{
+			columns: []string{"title"},
+			rows:    [][]driver.Value{{"Loukoum"}},
+		}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		title := ""
+		row := executor.QueryRow(ctx, loukoum.Select("title").From("news").Where(loukoum.Condition("id").Equal(1)))
+		is.NoError(row.Err())
+		is.NoError(row.Scan(&title))
+		is.Equal("Loukoum", title)
+		is.Equal([]string{"SELECT title FROM news WHERE (id = $1)"}, fake.queries)
+	}
+	{
+		fake := &fakeDriver{columns: []string{"title"}}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		title := ""
+		row := executor.QueryRow(ctx, loukoum.Select("title").From("news"))
+		is.Equal(sql.ErrNoRows, row.Scan(&title))
+	}
+	{
+		fake := &fakeDriver{}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		title := ""
+		row := executor.QueryRow(ctx, loukoum.Select("title").From("news").Offset(-1))
+		is.True(errors.Is(row.Err(), loukoum.ErrInvalidOffset))
+		is.True(errors.Is(row.Scan(&title), loukoum.ErrInvalidOffset))
+		is.Empty(fake.queries)
+	}
+}
+
+func TestExecutor_Get(t *testing.T) {
+	is := require.New(t)
+	ctx := context.Background()
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Structs are scanned using their "db" tag, including nested structs...
+	{
+		fake := &fakeDriver{
+			columns: []string{"id", "title", "summary", "published_at", "author.id", "author.name"},
+			rows:    [][]driver.Value{{int64(1), "Loukoum", nil, when, int64(2), "ulule"}},
+		}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		news := News{}
+		err := executor.Get(ctx, loukoum.
+			Select(loukoum.StructColumns(news).Table("n").Join("author", "a")).
+			From(loukoum.Table("news").As("n")).
+			Join(loukoum.Table("authors").As("a"), loukoum.On("a.id", "n.author_id")).
+			Where(loukoum.Condition("n.id").Equal(1)), &news)
+		is.NoError(err)
+		is.Equal(int64(1), news.ID)
+		is.Equal("Loukoum", news.Title)
+		is.False(news.Summary.Valid)
+		is.NotNil(news.PublishedAt)
+		is.Equal(when, *news.PublishedAt)
+		is.Equal(&Author{ID: 2, Name: "ulule"}, news.Author)
+	}
+
+	// ...whereas single values are scanned as is.
+	{
+		fake := &fakeDriver{
+			columns: []string{"count"},
+			rows:    [][]driver.Value{{int64(3)}},
+		}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		count := 0
+		err := executor.Get(ctx, loukoum.Select(loukoum.Count("*")).From("news"), &count)
+		is.NoError(err)
+		is.Equal(3, count)
+	}
+
+	// Columns must be mapped to a field...
+	{
+		fake := &fakeDriver{
+			columns: []string{"id", "status"},
+			rows:    [][]driver.Value{{int64(1), "published"}},
+		}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		news := News{}
+		err := executor.Get(ctx, loukoum.Select("id", "status").From("news"), &news)
+		is.Error(err)
+		is.True(errors.Is(err, loukoum.ErrInvalidColumn))
+	}
+
+	// ...destinations must be pointers...
+	{
+		fake := &fakeDriver{}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		err := executor.Get(ctx, loukoum.Select("id").From("news"), News{})
+		is.True(errors.Is(err, exec.ErrInvalidDestination))
+		is.Empty(fake.queries)
+	}
+
+	// ...and queries must return a row.
+	{
+		fake := &fakeDriver{columns: []string{"id"}}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		news := News{}
+		err := executor.Get(ctx, loukoum.Select("id").From("news"), &news)
+		is.Equal(sql.ErrNoRows, err)
+	}
+}
+
+func TestExecutor_Select(t *testing.T) {
+	is := require.New(t)
+	ctx := context.Background()
+
+	{
+		fake := &fakeDriver{
+			columns: []string{"id", "title"},
+			rows:    [][]driver.Value{{int64(1), "Loukoum"}, {int64(2), "Ulule"}},
+		}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		news := []News{{ID: 42}}
+		err := executor.Select(ctx, loukoum.Select("id", "title").From("news").OrderBy(loukoum.Order("id")), &news)
+		is.NoError(err)
+		is.Equal([]News{{ID: 1, Title: "Loukoum"}, {ID: 2, Title: "Ulule"}}, news)
+	}
+	{
+		fake := &fakeDriver{
+			columns: []string{"id", "title"},
+			rows:    [][]driver.Value{{int64(1), "Loukoum"}},
+		}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		news := []*News{}
+		err := executor.Select(ctx, loukoum.Select("id", "title").From("news"), &news)
+		is.NoError(err)
+		is.Equal([]*News{{ID: 1, Title: "Loukoum"}}, news)
+	}
+	{
+		fake := &fakeDriver{
+			columns: []string{"id"},
+			rows:    [][]driver.Value{{int64(1)}, {int64(2)}},
+		}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		ids := []int64{}
+		err := executor.Select(ctx, loukoum.Select("id").From("news"), &ids)
+		is.NoError(err)
+		is.Equal([]int64{1, 2}, ids)
+	}
+	{
+		fake := &fakeDriver{
+			columns: []string{"id", "title"},
+			rows:    [][]driver.Value{{int64(1), "Loukoum"}},
+		}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL)
+
+		ids := []int64{}
+		err := executor.Select(ctx, loukoum.Select("id", "title").From("news"), &ids)
+		is.True(errors.Is(err, exec.ErrInvalidDestination))
+		is.Empty(ids)
+
+		news := News{}
+		err = executor.Select(ctx, loukoum.Select("id", "title").From("news"), &news)
+		is.True(errors.Is(err, exec.ErrInvalidDestination))
+	}
+
+	// Transactions can be used instead of the database.
+	{
+		fake := &fakeDriver{
+			columns: []string{"id"},
+			rows:    [][]driver.Value{{int64(1)}},
+		}
+		db := openFake(fake)
+		tx, err := db.Begin()
+		is.NoError(err)
+
+		ids := []int64{}
+		err = exec.New(db, loukoum.PostgreSQL).DB(tx).Select(ctx, loukoum.Select("id").From("news"), &ids)
+		is.NoError(err)
+		is.Equal([]int64{1}, ids)
+		is.NoError(tx.Commit())
+	}
+}
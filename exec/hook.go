@@ -0,0 +1,200 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ulule/loukoum/v3/builder"
+)
+
+// Kind is the kind of an executed statement.
+type Kind string
+
+// Statement kinds.
+const (
+	// Select is used for "SELECT" statements, including "UNION", "INTERSECT" and "EXCEPT" queries.
+	Select = Kind("SELECT")
+	// Insert is used for "INSERT" statements.
+	Insert = Kind("INSERT")
+	// Update is used for "UPDATE" statements.
+	Update = Kind("UPDATE")
+	// Delete is used for "DELETE" statements.
+	Delete = Kind("DELETE")
+	// Unknown is used for statements of other builders.
+	Unknown = Kind("UNKNOWN")
+)
+
+func (e Kind) String() string {
+	return string(e)
+}
+
+// toKind returns the kind of statement generated by given builder.
+func toKind(b builder.Builder) Kind {
+	switch {
+	case builder.IsSelectBuilder(b):
+		return Select
+	case builder.IsInsertBuilder(b):
+		return Insert
+	case builder.IsUpdateBuilder(b):
+		return Update
+	case builder.IsDeleteBuilder(b):
+		return Delete
+	}
+	if _, ok := deref(b).(builder.Compound); ok {
+		return Select
+	}
+	return Unknown
+}
+
+// Event describes a statement executed by an Executor.
+type Event struct {
+	// Kind is the kind of statement.
+	Kind Kind
+	// Query is the executed query.
+	Query string
+	// Args is the number of arguments of the query.
+	Args int
+	// Duration is the execution time of the statement, including rows scanning for Get and Select.
+	// It's undefined before execution.
+	Duration time.Duration
+	// Err is the error returned by the execution, if any.
+	// It's undefined before execution.
+	Err error
+}
+
+// Hook is called before and after every statement executed by an Executor.
+// Statements that cannot be built are never executed, so hooks aren't called for them.
+type Hook interface {
+	// Before is called before the statement is executed.
+	// The returned context is used to execute the statement, and is given to After, which allows tracing.
+	Before(ctx context.Context, event Event) context.Context
+	// After is called once the statement is executed.
+	After(ctx context.Context, event Event)
+}
+
+// Logger is a hook that logs executed statements using slog.
+// Statements are logged with debug level, or with error level if they fail.
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new Logger using given logger, or slog.Default() if it's nil.
+func NewLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return Logger{
+		logger: logger,
+	}
+}
+
+// Before is called before the statement is executed.
+func (Logger) Before(ctx context.Context, event Event) context.Context {
+	return ctx
+}
+
+// After is called once the statement is executed.
+func (logger Logger) After(ctx context.Context, event Event) {
+	level := slog.LevelDebug
+	attrs := []slog.Attr{
+		slog.String("kind", event.Kind.String()),
+		slog.String("query", event.Query),
+		slog.Int("args", event.Args),
+		slog.Duration("duration", event.Duration),
+	}
+
+	if isFailure(event.Err) {
+		level = slog.LevelError
+		attrs = append(attrs, slog.Any("error", event.Err))
+	}
+
+	logger.logger.LogAttrs(ctx, level, "loukoum: statement executed", attrs...)
+}
+
+// Recorder records metrics of executed statements.
+type Recorder interface {
+	Record(kind Kind, duration time.Duration, err error)
+}
+
+// Metrics is a hook that reports executed statements to a Recorder.
+type Metrics struct {
+	recorder Recorder
+}
+
+// NewMetrics creates a new Metrics using given recorder.
+func NewMetrics(recorder Recorder) Metrics {
+	return Metrics{
+		recorder: recorder,
+	}
+}
+
+// Before is called before the statement is executed.
+func (Metrics) Before(ctx context.Context, event Event) context.Context {
+	return ctx
+}
+
+// After is called once the statement is executed.
+func (metrics Metrics) After(ctx context.Context, event Event) {
+	metrics.recorder.Record(event.Kind, event.Duration, event.Err)
+}
+
+// Stat is the metrics of a kind of statement.
+type Stat struct {
+	// Count is the number of executed statements.
+	Count int
+	// Errors is the number of failed statements.
+	Errors int
+	// Duration is the total execution time of the statements.
+	Duration time.Duration
+}
+
+// Stats is an in-memory Recorder, which aggregates metrics by kind of statement.
+type Stats struct {
+	mutex sync.Mutex
+	stats map[Kind]Stat
+}
+
+// NewStats creates a new Stats instance.
+func NewStats() *Stats {
+	return &Stats{
+		stats: make(map[Kind]Stat),
+	}
+}
+
+// Record records the execution of a statement.
+func (stats *Stats) Record(kind Kind, duration time.Duration, err error) {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+
+	stat := stats.stats[kind]
+	stat.Count++
+	stat.Duration += duration
+	if isFailure(err) {
+		stat.Errors++
+	}
+	stats.stats[kind] = stat
+}
+
+// Stat returns the metrics of given kind of statement.
+func (stats *Stats) Stat(kind Kind) Stat {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+
+	return stats.stats[kind]
+}
+
+// isFailure returns true if given error is a failure, sql.ErrNoRows being an expected outcome.
+func isFailure(err error) bool {
+	return err != nil && !errors.Is(err, sql.ErrNoRows)
+}
+
+// Ensure that Logger and Metrics are hooks, and that Stats is a Recorder
+var (
+	_ Hook     = Logger{}
+	_ Hook     = Metrics{}
+	_ Recorder = &Stats{}
+)
@@ -0,0 +1,170 @@
+package exec_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3"
+	"github.com/ulule/loukoum/v3/exec"
+)
+
+type hookKey struct{}
+
+// recordingHook records the events it receives, and the context value given to After.
+type recordingHook struct {
+	name   string
+	calls  *[]string
+	events []exec.Event
+}
+
+func (hook *recordingHook) Before(ctx context.Context, event exec.Event) context.Context {
+	*hook.calls = append(*hook.calls, "before "+hook.name)
+	return context.WithValue(ctx, hookKey{}, hook.name)
+}
+
+func (hook *recordingHook) After(ctx context.Context, event exec.Event) {
+	*hook.calls = append(*hook.calls, "after "+hook.name+" with "+ctx.Value(hookKey{}).(string))
+	hook.events = append(hook.events, event)
+}
+
+func TestExecutor_Hooks(t *testing.T) {
+	is := require.New(t)
+	ctx := context.Background()
+
+	// Hooks receive the statement and its outcome, and wrap the execution in order...
+	{
+		calls := []string{}
+		first := &recordingHook{name: "first", calls: &calls}
+		second := &recordingHook{name: "second", calls: &calls}
+
+		fake := &fakeDriver{}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL).Hooks(first).Hooks(second)
+
+		_, err := executor.Exec(ctx, loukoum.Update("news").
+			Set(loukoum.Pair("status", "published")).
+			Where(loukoum.Condition("id").Equal(1)))
+		is.NoError(err)
+		is.Equal([]string{
+			"before first",
+			"before second",
+			"after second with second",
+			"after first with second",
+		}, calls)
+
+		is.Len(first.events, 1)
+		is.Equal(exec.Update, first.events[0].Kind)
+		is.Equal("UPDATE news SET status = $1 WHERE (id = $2)", first.events[0].Query)
+		is.Equal(2, first.events[0].Args)
+		is.NoError(first.events[0].Err)
+		is.Equal(first.events, second.events)
+	}
+
+	// ...including its error...
+	{
+		calls := []string{}
+		hook := &recordingHook{name: "hook", calls: &calls}
+
+		fake := &fakeDriver{err: errors.New("fake: connection refused")}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL).Hooks(hook)
+
+		ids := []int64{}
+		err := executor.Select(ctx, loukoum.Select("id").From("news"), &ids)
+		is.Error(err)
+		is.Len(hook.events, 1)
+		is.Equal(exec.Select, hook.events[0].Kind)
+		is.Equal(err, hook.events[0].Err)
+		is.True(errors.Is(hook.events[0].Err, fake.err))
+	}
+
+	// ...whereas invalid builders are never executed.
+	{
+		calls := []string{}
+		hook := &recordingHook{name: "hook", calls: &calls}
+
+		fake := &fakeDriver{}
+		executor := exec.New(openFake(fake), loukoum.PostgreSQL).Hooks(hook)
+
+		_, err := executor.Exec(ctx, loukoum.Select("id").From("news").Limit(0))
+		is.Error(err)
+		is.Empty(calls)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	is := require.New(t)
+	ctx := context.Background()
+
+	buffer := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	fake := &fakeDriver{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}
+	executor := exec.New(openFake(fake), loukoum.PostgreSQL).Hooks(exec.NewLogger(logger))
+
+	id := int64(0)
+	err := executor.Get(ctx, loukoum.Select("id").From("news").Where(loukoum.Condition("id").Equal(1)), &id)
+	is.NoError(err)
+
+	line := buffer.String()
+	is.Contains(line, "level=DEBUG")
+	is.Contains(line, `msg="loukoum: statement executed"`)
+	is.Contains(line, "kind=SELECT")
+	is.Contains(line, `query="SELECT id FROM news WHERE (id = $1)"`)
+	is.Contains(line, "args=1")
+	is.NotContains(line, "error=")
+
+	buffer.Reset()
+	fake.err = errors.New("fake: connection refused")
+
+	_, err = executor.Exec(ctx, loukoum.Delete("news"))
+	is.Error(err)
+
+	line = buffer.String()
+	is.Contains(line, "level=ERROR")
+	is.Contains(line, "kind=DELETE")
+	is.Contains(line, `error="loukoum: fake: connection refused (query: DELETE FROM news)"`)
+	is.Equal(1, strings.Count(line, "\n"))
+}
+
+func TestMetrics(t *testing.T) {
+	is := require.New(t)
+	ctx := context.Background()
+
+	stats := exec.NewStats()
+	fake := &fakeDriver{columns: []string{"id"}}
+	executor := exec.New(openFake(fake), loukoum.PostgreSQL).Hooks(exec.NewMetrics(stats))
+
+	id := int64(0)
+	err := executor.Get(ctx, loukoum.Select("id").From("news"), &id)
+	is.Equal(sql.ErrNoRows, err)
+
+	_, err = executor.Exec(ctx, loukoum.Insert("news").Set(loukoum.Pair("title", "Loukoum")))
+	is.NoError(err)
+
+	fake.err = errors.New("fake: connection refused")
+	_, err = executor.Exec(ctx, loukoum.Insert("news").Set(loukoum.Pair("title", "Loukoum")))
+	is.Error(err)
+
+	_, err = executor.Exec(ctx, loukoum.Select("id").From("news").
+		Union(loukoum.Select("id").From("archives")))
+	is.Error(err)
+
+	// sql.ErrNoRows isn't reported as an error.
+	selects := stats.Stat(exec.Select)
+	is.Equal(2, selects.Count)
+	is.Equal(1, selects.Errors)
+
+	inserts := stats.Stat(exec.Insert)
+	is.Equal(2, inserts.Count)
+	is.Equal(1, inserts.Errors)
+	is.True(inserts.Duration > 0)
+
+	is.Equal(exec.Stat{}, stats.Stat(exec.Delete))
+}
@@ -0,0 +1,163 @@
+package exec
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+
+	"github.com/ulule/loukoum/v3/builder"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// toDestination returns the value pointed at by given destination.
+func toDestination(dest interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return reflect.Value{}, types.NewErrorf(ErrInvalidDestination, "cannot use %T as destination pointer", dest)
+	}
+	return value.Elem(), nil
+}
+
+// toSliceDestination returns the slice pointed at by given destination.
+func toSliceDestination(dest interface{}) (reflect.Value, error) {
+	value, err := toDestination(dest)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if value.Kind() != reflect.Slice {
+		return reflect.Value{}, types.NewErrorf(ErrInvalidDestination, "cannot use %T as slice pointer", dest)
+	}
+	return value, nil
+}
+
+// scanRow scans the current row into given value.
+func scanRow(rows *sql.Rows, value reflect.Value) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	s, err := newScanner(columns, value.Type())
+	if err != nil {
+		return err
+	}
+
+	return s.scan(rows, value)
+}
+
+// scanRows scans every rows into given slice, replacing its elements.
+func scanRows(rows *sql.Rows, slice reflect.Value) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	kind := slice.Type().Elem()
+	pointer := kind.Kind() == reflect.Ptr
+	if pointer {
+		kind = kind.Elem()
+	}
+
+	s, err := newScanner(columns, kind)
+	if err != nil {
+		return err
+	}
+
+	list := reflect.MakeSlice(slice.Type(), 0, 0)
+	for rows.Next() {
+		value := reflect.New(kind)
+		err = s.scan(rows, value.Elem())
+		if err != nil {
+			return err
+		}
+		if pointer {
+			list = reflect.Append(list, value)
+		} else {
+			list = reflect.Append(list, value.Elem())
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	slice.Set(list)
+	return nil
+}
+
+// scanner scans rows into a struct, using the index sequence of the field of each column,
+// or into a single value if it has no index.
+type scanner struct {
+	columns []string
+	indexes [][]int
+}
+
+func newScanner(columns []string, kind reflect.Type) (scanner, error) {
+	if isScannable(kind) {
+		if len(columns) != 1 {
+			return scanner{}, types.NewErrorf(ErrInvalidDestination,
+				"cannot scan %d columns into %s", len(columns), kind)
+		}
+		return scanner{columns: columns}, nil
+	}
+
+	fields := builder.StructIndexes(kind)
+	indexes := make([][]int, len(columns))
+	for i := range columns {
+		index, ok := fields[columns[i]]
+		if !ok {
+			return scanner{}, types.NewErrorf(types.ErrInvalidColumn,
+				"missing destination field for column %s in %s", columns[i], kind)
+		}
+		indexes[i] = index
+	}
+
+	return scanner{columns: columns, indexes: indexes}, nil
+}
+
+func (s scanner) scan(rows *sql.Rows, value reflect.Value) error {
+	if s.indexes == nil {
+		return rows.Scan(value.Addr().Interface())
+	}
+
+	targets := make([]interface{}, len(s.indexes))
+	for i := range s.indexes {
+		field, ok := fieldByIndex(value, s.indexes[i])
+		if !ok {
+			return types.NewErrorf(ErrInvalidDestination,
+				"cannot allocate embedded struct of column %s in %s", s.columns[i], value.Type())
+		}
+		targets[i] = field.Addr().Interface()
+	}
+
+	return rows.Scan(targets...)
+}
+
+// isScannable returns true if given type is scanned as a single value, such as time.Time or sql.NullString.
+func isScannable(kind reflect.Type) bool {
+	return kind.Kind() != reflect.Struct || kind == timeType || reflect.PtrTo(kind).Implements(scannerType)
+}
+
+// fieldByIndex returns the field of given struct with given index sequence, allocating nil embedded and
+// nested structs, or false if such a struct cannot be allocated.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				if !value.CanSet() {
+					return reflect.Value{}, false
+				}
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+	return value, true
+}
@@ -1,9 +1,19 @@
 module github.com/ulule/loukoum/v3
 
+go 1.21
+
 require (
+	github.com/Masterminds/squirrel v1.5.4
 	github.com/jmoiron/sqlx v1.2.0
 	github.com/lib/pq v1.0.0
 	github.com/pkg/errors v0.8.1
 	github.com/stretchr/testify v1.3.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/lann/builder v0.0.0-20180802200727-47ae307949d0 // indirect
+	github.com/lann/ps v0.0.0-20150810152359-62de8c46ede0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	google.golang.org/appengine v1.4.0 // indirect
 )
@@ -3,6 +3,7 @@ package loukoum
 import (
 	"github.com/ulule/loukoum/v3/builder"
 	"github.com/ulule/loukoum/v3/stmt"
+	"github.com/ulule/loukoum/v3/token"
 	"github.com/ulule/loukoum/v3/types"
 )
 
@@ -21,6 +22,74 @@ const (
 	Asc = types.Asc
 	// Desc is used for "ORDER BY" statement.
 	Desc = types.Desc
+	// IndexNaming names arguments of named queries after their position, such as "arg_1".
+	IndexNaming = types.IndexNaming
+	// ColumnNaming names arguments of named queries after the column they're compared with or assigned to.
+	ColumnNaming = types.ColumnNaming
+	// QuoteNever writes identifiers as is.
+	QuoteNever = types.QuoteNever
+	// QuoteWhenNeeded quotes identifiers parts that are reserved words, use mixed case or special characters.
+	QuoteWhenNeeded = types.QuoteWhenNeeded
+	// QuoteAlways quotes every identifiers parts.
+	QuoteAlways = types.QuoteAlways
+	// MaxParameters is the maximum number of parameters of a PostgreSQL statement.
+	MaxParameters = builder.MaxParameters
+)
+
+var (
+	// PostgreSQL is the default dialect.
+	PostgreSQL = types.PostgreSQL
+	// MySQL is the dialect used for MySQL and MariaDB.
+	MySQL = types.MySQL
+	// SQLite is the dialect used for SQLite.
+	SQLite = types.SQLite
+)
+
+// Placeholder styles, used by QueryWith() and BuildWith() instead of the placeholders of the dialect.
+var (
+	// DollarPlaceholder is used for "$1" placeholders, as expected by PostgreSQL.
+	DollarPlaceholder = types.DollarPlaceholder
+	// QuestionPlaceholder is used for "?" placeholders, as expected by MySQL.
+	QuestionPlaceholder = types.QuestionPlaceholder
+	// RebindPlaceholder is used for "?" placeholders, whose literal "?" characters are escaped as "??",
+	// for a rebinder that unescapes them, such as the numbered formats of squirrel.
+	RebindPlaceholder = types.RebindPlaceholder
+	// AtPlaceholder is used for "@p1" placeholders, as expected by SQL Server.
+	AtPlaceholder = types.AtPlaceholder
+	// ColonPlaceholder is used for ":1" placeholders, as expected by Oracle.
+	ColonPlaceholder = types.ColonPlaceholder
+)
+
+// Errors kinds returned by Build() and BuildNamed(), that can be matched with errors.Is().
+var (
+	// ErrDuplicateClause is returned when a clause is defined twice.
+	ErrDuplicateClause = types.ErrDuplicateClause
+	// ErrInvalidLimit is returned when a limit isn't a positive integer.
+	ErrInvalidLimit = types.ErrInvalidLimit
+	// ErrInvalidOffset is returned when an offset isn't a non-negative integer.
+	ErrInvalidOffset = types.ErrInvalidOffset
+	// ErrInvalidColumn is returned when a column is undefined or has an unsupported type.
+	ErrInvalidColumn = types.ErrInvalidColumn
+	// ErrInvalidTable is returned when a table is undefined or has an unsupported type.
+	ErrInvalidTable = types.ErrInvalidTable
+	// ErrInvalidClause is returned when a clause is undefined or has an unsupported type.
+	ErrInvalidClause = types.ErrInvalidClause
+	// ErrInvalidExpression is returned when an expression is undefined or has an unsupported type.
+	ErrInvalidExpression = types.ErrInvalidExpression
+	// ErrInvalidCondition is returned when a condition is missing.
+	ErrInvalidCondition = types.ErrInvalidCondition
+	// ErrInvalidPairs is returned when pairs of a SET clause are invalid.
+	ErrInvalidPairs = types.ErrInvalidPairs
+	// ErrInvalidIdentifier is returned when an identifier is rejected by a strict dialect.
+	ErrInvalidIdentifier = types.ErrInvalidIdentifier
+	// ErrEmptyStatement is returned when a statement lacks a required clause.
+	ErrEmptyStatement = types.ErrEmptyStatement
+	// ErrUnsupportedFeature is returned when a feature isn't supported by a dialect.
+	ErrUnsupportedFeature = types.ErrUnsupportedFeature
+	// ErrTooManyParameters is returned when a statement cannot be split under a parameters limit.
+	ErrTooManyParameters = types.ErrTooManyParameters
+	// ErrInvalidParameter is returned when a named parameter is invalid, missing or unknown.
+	ErrInvalidParameter = types.ErrInvalidParameter
 )
 
 // Map is a key/value map.
@@ -31,6 +100,20 @@ func Pair(key, value interface{}) types.Pair {
 	return types.Pair{Key: key, Value: value}
 }
 
+// StructMap takes a struct, or a pointer to a struct, and returns a Map of its fields using their "db" tag.
+// Fields tagged with "-" are ignored, so are fields tagged with "omitempty" option if they have a zero value.
+// The map can be restricted with Only() or Without(), and is accepted by Set() and DoUpdate().
+func StructMap(value interface{}) Map {
+	return builder.ToMap(value)
+}
+
+// StructColumns generates the columns of given struct, or pointer to a struct, using the "db" tag of its fields.
+// It's accepted by Select(): columns can be prefixed by a table with Table() and Join(),
+// or excluded with Without().
+func StructColumns(model interface{}) builder.StructColumns {
+	return builder.NewStructColumns(model)
+}
+
 // Select starts a SelectBuilder using the given columns.
 func Select(columns ...interface{}) builder.Select {
 	return builder.NewSelect().Columns(columns...)
@@ -85,11 +168,94 @@ func Or(left stmt.Expression, right stmt.Expression) stmt.InfixExpression {
 	return stmt.NewInfixExpression(left, stmt.NewLogicalOperator(types.Or), right)
 }
 
+// Func is a wrapper to create a new Func expression.
+// Arguments are either columns, expressions or values that are bound to the query.
+func Func(name string, args ...interface{}) stmt.Func {
+	return stmt.NewFunc(name, args...)
+}
+
+// Case is a wrapper to create a new searched Case expression.
+func Case() stmt.Case {
+	return stmt.NewCase()
+}
+
+// CaseOf is a wrapper to create a new simple Case expression, that compares given value with each WHEN operand.
+func CaseOf(value interface{}) stmt.Case {
+	return stmt.NewSimpleCase(value)
+}
+
+// Window is a wrapper to create a new Window specification, used by Over() or by a WINDOW clause.
+func Window() stmt.Window {
+	return stmt.NewWindow()
+}
+
+// UnboundedPreceding is a wrapper to create a window frame bound on the first row of the partition.
+func UnboundedPreceding() stmt.FrameBound {
+	return stmt.NewFrameBound(types.UnboundedPreceding, 0)
+}
+
+// Preceding is a wrapper to create a window frame bound at given offset before the current row.
+func Preceding(offset int64) stmt.FrameBound {
+	return stmt.NewFrameBound(types.Preceding, offset)
+}
+
+// CurrentRow is a wrapper to create a window frame bound on the current row.
+func CurrentRow() stmt.FrameBound {
+	return stmt.NewFrameBound(types.CurrentRow, 0)
+}
+
+// Following is a wrapper to create a window frame bound at given offset after the current row.
+func Following(offset int64) stmt.FrameBound {
+	return stmt.NewFrameBound(types.Following, offset)
+}
+
+// UnboundedFollowing is a wrapper to create a window frame bound on the last row of the partition.
+func UnboundedFollowing() stmt.FrameBound {
+	return stmt.NewFrameBound(types.UnboundedFollowing, 0)
+}
+
+// Negate is a wrapper to create a new UnaryExpression statement using "-" operator.
+func Negate(value interface{}) stmt.UnaryExpression {
+	return stmt.NewUnaryExpression(types.Negate, stmt.NewOperand(value))
+}
+
+// BitwiseNot is a wrapper to create a new UnaryExpression statement using "~" operator.
+func BitwiseNot(value interface{}) stmt.UnaryExpression {
+	return stmt.NewUnaryExpression(types.BitwiseNot, stmt.NewOperand(value))
+}
+
 // Raw is a wrapper to create a new Raw expression.
 func Raw(value string) stmt.Raw {
 	return stmt.NewRaw(value)
 }
 
+// Default is a wrapper to create a DEFAULT expression, that inserts or sets the default value of a column.
+func Default() stmt.Raw {
+	return stmt.NewRaw(token.Default.String())
+}
+
+// Param is a wrapper to create a named parameter, whose value is given when the query is executed
+// using Bind() or BindNamed().
+func Param(name string) stmt.Param {
+	return stmt.NewParam(name)
+}
+
+// Bind replaces the named parameters of given positional arguments by given values, which is a map or a struct.
+func Bind(args []interface{}, values interface{}) ([]interface{}, error) {
+	return builder.Bind(args, values)
+}
+
+// BindNamed replaces the named parameters of given named arguments by given values, which is a map or a struct.
+func BindNamed(args map[string]interface{}, values interface{}) (map[string]interface{}, error) {
+	return builder.BindNamed(args, values)
+}
+
+// Compile generates the query of given builder once, as a template whose named parameters are bound on
+// each execution.
+func Compile(query builder.Builder) (builder.Template, error) {
+	return builder.Compile(query)
+}
+
 // Exists is a wrapper to create a new Exists expression.
 func Exists(value interface{}) stmt.Exists {
 	return stmt.NewExists(value)
@@ -140,11 +306,22 @@ func Update(table interface{}) builder.Update {
 	return builder.NewUpdate(table)
 }
 
+// Batch splits given values in chunks, and builds a query for each of them using given function,
+// so that every query has at most limit parameters. It's used for large IN lists.
+func Batch(values []interface{}, limit int, build func(values []interface{}) builder.Builder) ([]builder.Chunk, error) {
+	return builder.Batch(values, limit, build)
+}
+
 // DoNothing is a wrapper to create a new ConflictNoAction statement.
 func DoNothing() stmt.ConflictNoAction {
 	return stmt.NewConflictNoAction()
 }
 
+// Excluded is a wrapper to create a new Excluded expression.
+func Excluded(column string) stmt.Excluded {
+	return stmt.NewExcluded(column)
+}
+
 // DoUpdate is a wrapper to create a new ConflictUpdateAction statement.
 func DoUpdate(args ...interface{}) stmt.ConflictUpdateAction {
 	return stmt.NewConflictUpdateAction(builder.ToSet(args))
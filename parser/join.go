@@ -148,7 +148,7 @@ func ParseJoin(subquery string) (stmt.Join, error) { // nolint: gocyclo
 func MustParseJoin(subquery string) stmt.Join {
 	join, err := ParseJoin(subquery)
 	if err != nil {
-		panic(fmt.Sprintf("loukoum: %s", err))
+		panic(types.NewError(types.ErrInvalidClause, err.Error()))
 	}
 	return join
 }
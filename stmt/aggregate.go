@@ -9,6 +9,7 @@ import (
 type Count struct {
 	Value      Raw
 	IsDistinct bool
+	Window     *Window
 	Alias      string
 }
 
@@ -31,6 +32,12 @@ func (count Count) Distinct(value bool) Count {
 	return count
 }
 
+// Over defines the window of the COUNT function, which makes it a window function.
+func (count Count) Over(window Window) Count {
+	count.Window = &window
+	return count
+}
+
 // Write exposes statement as a SQL query.
 func (count Count) Write(ctx types.Context) {
 	ctx.Write(token.Count.String())
@@ -41,6 +48,7 @@ func (count Count) Write(ctx types.Context) {
 	}
 	count.Value.Write(ctx)
 	ctx.Write(")")
+	writeOver(ctx, count.Window)
 	if count.Alias != "" {
 		ctx.Write(" ")
 		ctx.Write(token.As.String())
@@ -61,8 +69,9 @@ var _ SelectExpression = Count{}
 
 // Max is a aggregate expression.
 type Max struct {
-	Value Raw
-	Alias string
+	Value  Raw
+	Window *Window
+	Alias  string
 }
 
 // NewMax returns a new Max instance.
@@ -78,12 +87,19 @@ func (max Max) As(alias string) Max {
 	return max
 }
 
+// Over defines the window of the MAX function, which makes it a window function.
+func (max Max) Over(window Window) Max {
+	max.Window = &window
+	return max
+}
+
 // Write exposes statement as a SQL query.
 func (max Max) Write(ctx types.Context) {
 	ctx.Write(token.Max.String())
 	ctx.Write("(")
 	max.Value.Write(ctx)
 	ctx.Write(")")
+	writeOver(ctx, max.Window)
 	if max.Alias != "" {
 		ctx.Write(" ")
 		ctx.Write(token.As.String())
@@ -104,8 +120,9 @@ var _ SelectExpression = Max{}
 
 // Min is a aggregate expression.
 type Min struct {
-	Value Raw
-	Alias string
+	Value  Raw
+	Window *Window
+	Alias  string
 }
 
 // NewMin returns a new Min instance.
@@ -121,12 +138,19 @@ func (min Min) As(alias string) Min {
 	return min
 }
 
+// Over defines the window of the MIN function, which makes it a window function.
+func (min Min) Over(window Window) Min {
+	min.Window = &window
+	return min
+}
+
 // Write exposes statement as a SQL query.
 func (min Min) Write(ctx types.Context) {
 	ctx.Write(token.Min.String())
 	ctx.Write("(")
 	min.Value.Write(ctx)
 	ctx.Write(")")
+	writeOver(ctx, min.Window)
 	if min.Alias != "" {
 		ctx.Write(" ")
 		ctx.Write(token.As.String())
@@ -147,8 +171,9 @@ var _ SelectExpression = Min{}
 
 // Sum is a aggregate expression.
 type Sum struct {
-	Value Raw
-	Alias string
+	Value  Raw
+	Window *Window
+	Alias  string
 }
 
 // NewSum returns a new Sum instance.
@@ -164,12 +189,19 @@ func (sum Sum) As(alias string) Sum {
 	return sum
 }
 
+// Over defines the window of the SUM function, which makes it a window function.
+func (sum Sum) Over(window Window) Sum {
+	sum.Window = &window
+	return sum
+}
+
 // Write exposes statement as a SQL query.
 func (sum Sum) Write(ctx types.Context) {
 	ctx.Write(token.Sum.String())
 	ctx.Write("(")
 	sum.Value.Write(ctx)
 	ctx.Write(")")
+	writeOver(ctx, sum.Window)
 	if sum.Alias != "" {
 		ctx.Write(" ")
 		ctx.Write(token.As.String())
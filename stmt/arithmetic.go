@@ -0,0 +1,238 @@
+package stmt
+
+import (
+	"github.com/ulule/loukoum/v3/token"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// ----------------------------------------------------------------------------
+// ArithmeticExpression
+// ----------------------------------------------------------------------------
+
+// ArithmeticExpression is an Expression that computes a value from a left and right operand.
+// For example, the expression 'price * quantity' is an arithmetic expression.
+type ArithmeticExpression struct {
+	Left     Expression
+	Operator ArithmeticOperator
+	Right    Expression
+	Alias    string
+}
+
+// NewArithmeticExpression returns a new ArithmeticExpression instance.
+func NewArithmeticExpression(left Expression, operator ArithmeticOperator, right Expression) ArithmeticExpression {
+	return ArithmeticExpression{
+		Left:     left,
+		Operator: operator,
+		Right:    right,
+	}
+}
+
+// newArithmeticExpression returns a new ArithmeticExpression using given operator and right operand.
+func newArithmeticExpression(left Expression, operator types.ArithmeticOperator,
+	value interface{}) ArithmeticExpression {
+	return NewArithmeticExpression(withoutAlias(left), NewArithmeticOperator(operator), NewOperand(value))
+}
+
+func (ArithmeticExpression) expression() {}
+
+func (ArithmeticExpression) selectExpression() {}
+
+// Write exposes statement as a SQL query.
+func (expression ArithmeticExpression) Write(ctx types.Context) {
+	if expression.IsEmpty() {
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "expression is undefined"))
+		return
+	}
+
+	if expression.Operator.Operator == types.Concat && !ctx.Dialect().Supports(types.FeatureConcatOperator) {
+		// For example, MySQL evaluates "||" as a logical OR.
+		ctx.Write("CONCAT(")
+		withoutAlias(expression.Left).Write(ctx)
+		ctx.Write(", ")
+		withoutAlias(expression.Right).Write(ctx)
+		ctx.Write(")")
+	} else {
+		ctx.Write("(")
+		withoutAlias(expression.Left).Write(ctx)
+		ctx.Write(" ")
+		expression.Operator.Write(ctx)
+		ctx.Write(" ")
+		withoutAlias(expression.Right).Write(ctx)
+		ctx.Write(")")
+	}
+
+	if expression.Alias != "" {
+		ctx.Write(" ")
+		ctx.Write(token.As.String())
+		ctx.Write(" ")
+		writeAlias(ctx, expression.Alias)
+	}
+}
+
+// IsEmpty returns true if statement is undefined.
+func (expression ArithmeticExpression) IsEmpty() bool {
+	return expression.Left == nil || expression.Right == nil ||
+		expression.Left.IsEmpty() || expression.Operator.IsEmpty() || expression.Right.IsEmpty()
+}
+
+// As is used to give an alias name to the expression.
+func (expression ArithmeticExpression) As(alias string) ArithmeticExpression {
+	expression.Alias = alias
+	return expression
+}
+
+// Add performs an addition.
+func (expression ArithmeticExpression) Add(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.Add, value)
+}
+
+// Subtract performs a subtraction.
+func (expression ArithmeticExpression) Subtract(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.Subtract, value)
+}
+
+// Multiply performs a multiplication.
+func (expression ArithmeticExpression) Multiply(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.Multiply, value)
+}
+
+// Divide performs a division.
+func (expression ArithmeticExpression) Divide(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.Divide, value)
+}
+
+// Modulo performs a modulo.
+func (expression ArithmeticExpression) Modulo(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.Modulo, value)
+}
+
+// Concat performs a string concatenation.
+func (expression ArithmeticExpression) Concat(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.Concat, value)
+}
+
+// BitwiseAnd performs a bitwise "and".
+func (expression ArithmeticExpression) BitwiseAnd(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.BitwiseAnd, value)
+}
+
+// BitwiseOr performs a bitwise "or".
+func (expression ArithmeticExpression) BitwiseOr(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.BitwiseOr, value)
+}
+
+// BitwiseShiftLeft performs a bitwise shift left.
+func (expression ArithmeticExpression) BitwiseShiftLeft(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.BitwiseShiftLeft, value)
+}
+
+// BitwiseShiftRight performs a bitwise shift right.
+func (expression ArithmeticExpression) BitwiseShiftRight(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(expression, types.BitwiseShiftRight, value)
+}
+
+// Equal performs an "equal" comparison.
+func (expression ArithmeticExpression) Equal(value interface{}) InfixExpression {
+	return compare(expression, types.Equal, value)
+}
+
+// NotEqual performs a "not equal" comparison.
+func (expression ArithmeticExpression) NotEqual(value interface{}) InfixExpression {
+	return compare(expression, types.NotEqual, value)
+}
+
+// GreaterThan performs a "greater than" comparison.
+func (expression ArithmeticExpression) GreaterThan(value interface{}) InfixExpression {
+	return compare(expression, types.GreaterThan, value)
+}
+
+// GreaterThanOrEqual performs a "greater than or equal to" comparison.
+func (expression ArithmeticExpression) GreaterThanOrEqual(value interface{}) InfixExpression {
+	return compare(expression, types.GreaterThanOrEqual, value)
+}
+
+// LessThan performs a "less than" comparison.
+func (expression ArithmeticExpression) LessThan(value interface{}) InfixExpression {
+	return compare(expression, types.LessThan, value)
+}
+
+// LessThanOrEqual performs a "less than or equal to" comparison.
+func (expression ArithmeticExpression) LessThanOrEqual(value interface{}) InfixExpression {
+	return compare(expression, types.LessThanOrEqual, value)
+}
+
+// Ensure that ArithmeticExpression is an Expression
+var _ Expression = ArithmeticExpression{}
+
+// Ensure that ArithmeticExpression is a SelectExpression
+var _ SelectExpression = ArithmeticExpression{}
+
+// ----------------------------------------------------------------------------
+// UnaryExpression
+// ----------------------------------------------------------------------------
+
+// UnaryExpression is an Expression that has a single operand with a prefix operator.
+// For example, the expression '-balance' is an unary expression.
+type UnaryExpression struct {
+	Operator types.UnaryOperator
+	Value    Expression
+	Alias    string
+}
+
+// NewUnaryExpression returns a new UnaryExpression instance.
+func NewUnaryExpression(operator types.UnaryOperator, value Expression) UnaryExpression {
+	return UnaryExpression{
+		Operator: operator,
+		Value:    value,
+	}
+}
+
+func (UnaryExpression) expression() {}
+
+func (UnaryExpression) selectExpression() {}
+
+// Write exposes statement as a SQL query.
+func (expression UnaryExpression) Write(ctx types.Context) {
+	if expression.IsEmpty() {
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "expression is undefined"))
+		return
+	}
+
+	ctx.Write("(")
+	ctx.Write(expression.Operator.String())
+	value := withoutAlias(expression.Value)
+	switch value.(type) {
+	case Identifier, ArithmeticExpression, UnaryExpression, InfixExpression, Func, Wrapper, *Wrapper:
+		value.Write(ctx)
+	default:
+		// Operand is wrapped so that a negative value isn't written as a comment, such as "--1".
+		ctx.Write("(")
+		value.Write(ctx)
+		ctx.Write(")")
+	}
+	ctx.Write(")")
+
+	if expression.Alias != "" {
+		ctx.Write(" ")
+		ctx.Write(token.As.String())
+		ctx.Write(" ")
+		writeAlias(ctx, expression.Alias)
+	}
+}
+
+// IsEmpty returns true if statement is undefined.
+func (expression UnaryExpression) IsEmpty() bool {
+	return expression.Operator == "" || expression.Value == nil || expression.Value.IsEmpty()
+}
+
+// As is used to give an alias name to the expression.
+func (expression UnaryExpression) As(alias string) UnaryExpression {
+	expression.Alias = alias
+	return expression
+}
+
+// Ensure that UnaryExpression is an Expression
+var _ Expression = UnaryExpression{}
+
+// Ensure that UnaryExpression is a SelectExpression
+var _ SelectExpression = UnaryExpression{}
@@ -40,7 +40,8 @@ func (Between) expression() {}
 // Write exposes statement as a SQL query.
 func (between Between) Write(ctx types.Context) {
 	if between.IsEmpty() {
-		panic("loukoum: expression is undefined")
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "expression is undefined"))
+		return
 	}
 
 	ctx.Write("(")
@@ -48,11 +49,11 @@ func (between Between) Write(ctx types.Context) {
 	ctx.Write(" ")
 	between.Operator.Write(ctx)
 	ctx.Write(" ")
-	between.From.Write(ctx)
+	writeForColumn(ctx, between.Identifier.Identifier, between.From)
 	ctx.Write(" ")
 	between.And.Write(ctx)
 	ctx.Write(" ")
-	between.To.Write(ctx)
+	writeForColumn(ctx, between.Identifier.Identifier, between.To)
 	ctx.Write(")")
 }
 
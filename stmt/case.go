@@ -0,0 +1,150 @@
+package stmt
+
+import (
+	"github.com/ulule/loukoum/v3/token"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// Case is a CASE expression.
+// If Value is defined, it's a simple CASE expression that compares Value with each WHEN operand.
+// Otherwise, it's a searched CASE expression that evaluates each WHEN condition.
+type Case struct {
+	Value   Expression
+	Whens   []When
+	Default Expression
+	Alias   string
+}
+
+// NewCase returns a new searched Case instance.
+func NewCase() Case {
+	return Case{}
+}
+
+// NewSimpleCase returns a new simple Case instance, that compares given value with each WHEN operand.
+func NewSimpleCase(value interface{}) Case {
+	return Case{
+		Value: NewOperand(value),
+	}
+}
+
+// When adds a WHEN clause using given condition (or operand of a simple CASE) and its result.
+func (expression Case) When(condition interface{}, result interface{}) Case {
+	whens := make([]When, 0, len(expression.Whens)+1)
+	whens = append(whens, expression.Whens...)
+	expression.Whens = append(whens, NewWhen(NewOperand(condition), NewOperand(result)))
+	return expression
+}
+
+// Else defines the ELSE clause, which is the result if no condition is satisfied.
+func (expression Case) Else(result interface{}) Case {
+	expression.Default = NewOperand(result)
+	return expression
+}
+
+// As is used to give an alias name to the CASE expression.
+func (expression Case) As(alias string) Case {
+	expression.Alias = alias
+	return expression
+}
+
+// Asc is used to transform a CASE expression to an order expression.
+func (expression Case) Asc() Order {
+	if expression.Alias != "" {
+		return NewOrder(expression.Alias, types.Asc)
+	}
+	return NewOrderExpression(expression, types.Asc)
+}
+
+// Desc is used to transform a CASE expression to an order expression.
+func (expression Case) Desc() Order {
+	if expression.Alias != "" {
+		return NewOrder(expression.Alias, types.Desc)
+	}
+	return NewOrderExpression(expression, types.Desc)
+}
+
+func (Case) expression() {}
+
+func (Case) selectExpression() {}
+
+// Write exposes statement as a SQL query.
+func (expression Case) Write(ctx types.Context) {
+	if expression.IsEmpty() {
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "case expression requires at least one when clause"))
+		return
+	}
+
+	ctx.Write(token.Case.String())
+	if expression.Value != nil {
+		ctx.Write(" ")
+		expression.Value.Write(ctx)
+	}
+	for i := range expression.Whens {
+		ctx.Write(" ")
+		expression.Whens[i].Write(ctx)
+	}
+	if expression.Default != nil {
+		ctx.Write(" ")
+		ctx.Write(token.Else.String())
+		ctx.Write(" ")
+		expression.Default.Write(ctx)
+	}
+	ctx.Write(" ")
+	ctx.Write(token.End.String())
+
+	if expression.Alias != "" {
+		ctx.Write(" ")
+		ctx.Write(token.As.String())
+		ctx.Write(" ")
+		writeAlias(ctx, expression.Alias)
+	}
+}
+
+// IsEmpty returns true if statement is undefined.
+func (expression Case) IsEmpty() bool {
+	return len(expression.Whens) == 0
+}
+
+// Ensure that Case is an Expression
+var _ Expression = Case{}
+
+// Ensure that Case is a SelectExpression
+var _ SelectExpression = Case{}
+
+// When is a WHEN clause of a CASE expression.
+type When struct {
+	Condition Expression
+	Result    Expression
+}
+
+// NewWhen returns a new When instance.
+func NewWhen(condition, result Expression) When {
+	return When{
+		Condition: condition,
+		Result:    result,
+	}
+}
+
+// Write exposes statement as a SQL query.
+func (when When) Write(ctx types.Context) {
+	if when.IsEmpty() {
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "when clause is undefined"))
+		return
+	}
+
+	ctx.Write(token.When.String())
+	ctx.Write(" ")
+	when.Condition.Write(ctx)
+	ctx.Write(" ")
+	ctx.Write(token.Then.String())
+	ctx.Write(" ")
+	when.Result.Write(ctx)
+}
+
+// IsEmpty returns true if statement is undefined.
+func (when When) IsEmpty() bool {
+	return when.Condition == nil || when.Result == nil || when.Condition.IsEmpty() || when.Result.IsEmpty()
+}
+
+// Ensure that When is a Statement
+var _ Statement = When{}
@@ -0,0 +1,118 @@
+package stmt
+
+import (
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// Compound is a statement combining the results of two queries,
+// using UNION, UNION ALL, INTERSECT or EXCEPT operator.
+type Compound struct {
+	Left     Expression
+	Operator types.SetOperator
+	Right    Expression
+	OrderBy  OrderBy
+	Limit    Limit
+	Offset   Offset
+	// Errors are reported by the builder of the statement, if it's embedded in another one, such as a subquery.
+	Errors types.Errors
+}
+
+// NewCompound returns a new Compound instance.
+func NewCompound(left Expression, operator types.SetOperator, right Expression) Compound {
+	return Compound{
+		Left:     left,
+		Operator: operator,
+		Right:    right,
+	}
+}
+
+// Write exposes statement as a SQL query.
+func (compound Compound) Write(ctx types.Context) {
+	reportErrors(ctx, compound.Errors)
+	if compound.IsEmpty() {
+		ctx.Fail(types.NewError(types.ErrEmptyStatement, "compound statements must have two queries"))
+		return
+	}
+	if isLocked(compound.Left) || isLocked(compound.Right) {
+		ctx.Fail(types.NewErrorf(types.ErrInvalidClause, "locking clauses are not allowed with %s", compound.Operator))
+		return
+	}
+
+	writeCompoundOperand(ctx, compound.Left, compound.isLeftWrapped(ctx))
+	ctx.Write(" ")
+	ctx.Write(compound.Operator.String())
+	ctx.Write(" ")
+	writeCompoundOperand(ctx, compound.Right, isWrappedOperand(compound.Right))
+
+	if !compound.OrderBy.IsEmpty() {
+		ctx.Write(" ")
+		compound.OrderBy.Write(ctx)
+	}
+
+	if !compound.Limit.IsEmpty() {
+		ctx.Write(" ")
+		compound.Limit.Write(ctx)
+	}
+
+	if !compound.Offset.IsEmpty() {
+		ctx.Write(" ")
+		compound.Offset.Write(ctx)
+	}
+}
+
+// IsEmpty returns true if statement is undefined.
+func (compound Compound) IsEmpty() bool {
+	return compound.Left == nil || compound.Right == nil || compound.Operator == "" ||
+		compound.Left.IsEmpty() || compound.Right.IsEmpty()
+}
+
+func (Compound) expression() {}
+
+// hasTail returns true if compound has clauses applying to its result.
+func (compound Compound) hasTail() bool {
+	return !compound.OrderBy.IsEmpty() || !compound.Limit.IsEmpty() || !compound.Offset.IsEmpty()
+}
+
+// isLeftWrapped returns true if left operand requires parenthesis.
+// Compound statements are evaluated from left to right, but INTERSECT binds more tightly than UNION and EXCEPT
+// on dialects that support nested compound statements.
+func (compound Compound) isLeftWrapped(ctx types.Context) bool {
+	left, ok := compound.Left.(Compound)
+	if !ok || left.hasTail() {
+		return isWrappedOperand(compound.Left)
+	}
+	return ctx.Dialect().Supports(types.FeatureCompoundParenthesis) &&
+		compound.Operator == types.Intersect && left.Operator != types.Intersect
+}
+
+// isWrappedOperand returns true if given operand of a compound statement requires parenthesis.
+func isWrappedOperand(operand Expression) bool {
+	switch value := operand.(type) {
+	case Select:
+		return !value.With.IsEmpty() || !value.OrderBy.IsEmpty() || !value.Limit.IsEmpty() ||
+			!value.Offset.IsEmpty() || !value.Suffix.IsEmpty()
+	default:
+		return true
+	}
+}
+
+// isLocked returns true if given operand of a compound statement has a locking clause.
+func isLocked(operand Expression) bool {
+	selekt, ok := operand.(Select)
+	return ok && len(selekt.Locks) > 0
+}
+
+func writeCompoundOperand(ctx types.Context, operand Expression, wrapped bool) {
+	if !wrapped {
+		operand.Write(ctx)
+		return
+	}
+
+	requireFeature(ctx, types.FeatureCompoundParenthesis)
+	ctx.Write("(")
+	operand.Write(ctx)
+	ctx.Write(")")
+}
+
+// Ensure that Compound is an Expression
+var _ Expression = Compound{}
@@ -25,6 +25,13 @@ func (conflict OnConflict) Write(ctx types.Context) {
 		return
 	}
 
+	if ctx.Dialect().Supports(types.FeatureOnDuplicateKey) {
+		conflict.writeOnDuplicateKey(ctx)
+		return
+	}
+
+	requireFeature(ctx, types.FeatureOnConflict)
+
 	ctx.Write(token.On.String())
 	ctx.Write(" ")
 	ctx.Write(token.Conflict.String())
@@ -38,6 +45,27 @@ func (conflict OnConflict) Write(ctx types.Context) {
 	conflict.Action.Write(ctx)
 }
 
+// writeOnDuplicateKey exposes statement as a ON DUPLICATE KEY UPDATE clause.
+// Since this clause applies to every unique index of the table, conflict target is ignored.
+func (conflict OnConflict) writeOnDuplicateKey(ctx types.Context) {
+	action, ok := conflict.Action.(ConflictUpdateAction)
+	if !ok {
+		ctx.Fail(types.NewErrorf(types.ErrUnsupportedFeature,
+			"%s dialect doesn't support DO NOTHING action", ctx.Dialect().Name()))
+		return
+	}
+
+	ctx.Write(token.On.String())
+	ctx.Write(" ")
+	ctx.Write(token.Duplicate.String())
+	ctx.Write(" ")
+	ctx.Write(token.Key.String())
+	ctx.Write(" ")
+	ctx.Write(token.Update.String())
+	ctx.Write(" ")
+	action.Set.Pairs.Write(ctx)
+}
+
 // IsEmpty returns true if statement is undefined.
 func (conflict OnConflict) IsEmpty() bool {
 	return conflict.Action == nil || conflict.Action.IsEmpty()
@@ -111,6 +139,46 @@ func (action ConflictUpdateAction) IsEmpty() bool {
 
 func (ConflictUpdateAction) conflictAction() {}
 
+// Excluded is a reference to the value proposed for insertion in a DO UPDATE clause.
+type Excluded struct {
+	Column Column
+}
+
+// NewExcluded returns a new Excluded instance.
+func NewExcluded(column string) Excluded {
+	return Excluded{
+		Column: NewColumn(column),
+	}
+}
+
+func (Excluded) expression() {}
+
+// Write exposes statement as a SQL query.
+func (excluded Excluded) Write(ctx types.Context) {
+	if excluded.IsEmpty() {
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "excluded column is undefined"))
+		return
+	}
+
+	if ctx.Dialect().Supports(types.FeatureOnDuplicateKey) {
+		ctx.Write(token.Values.String())
+		ctx.Write("(")
+		writeIdentifier(ctx, excluded.Column.Name)
+		ctx.Write(")")
+		return
+	}
+
+	requireFeature(ctx, types.FeatureOnConflict)
+	ctx.Write(token.Excluded.String())
+	ctx.Write(".")
+	writeIdentifier(ctx, excluded.Column.Name)
+}
+
+// IsEmpty returns true if statement is undefined.
+func (excluded Excluded) IsEmpty() bool {
+	return excluded.Column.IsEmpty()
+}
+
 // ConflictNoAction is a DO NOTHING clause on ON CONFLICT expression.
 type ConflictNoAction struct{}
 
@@ -142,5 +210,8 @@ var _ Statement = ConflictTarget{}
 // Ensure that ConflictUpdateAction is a ConflictAction
 var _ ConflictAction = ConflictUpdateAction{}
 
+// Ensure that Excluded is an Expression
+var _ Expression = Excluded{}
+
 // Ensure that ConflictNoAction is a ConflictAction
 var _ ConflictAction = ConflictNoAction{}
@@ -7,10 +7,13 @@ import (
 
 // Delete is a DELETE statement.
 type Delete struct {
+	With      With
 	From      From
 	Using     Using
 	Where     Where
 	Returning Returning
+	// Errors are reported by the builder of the statement, if it's embedded in another one, such as a subquery.
+	Errors types.Errors
 }
 
 // NewDelete returns a new Delete instance.
@@ -20,8 +23,15 @@ func NewDelete() Delete {
 
 // Write exposes statement as a SQL query.
 func (delete Delete) Write(ctx types.Context) {
+	reportErrors(ctx, delete.Errors)
 	if delete.IsEmpty() {
-		panic("loukoum: a delete statement must have a table")
+		ctx.Fail(types.NewError(types.ErrEmptyStatement, "a delete statement must have a table"))
+		return
+	}
+
+	if !delete.With.IsEmpty() {
+		delete.With.Write(ctx)
+		ctx.Write(" ")
 	}
 
 	ctx.Write(token.Delete.String())
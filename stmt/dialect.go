@@ -0,0 +1,53 @@
+package stmt
+
+import (
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// writeIdentifier writes given identifier, quoted using context's dialect.
+func writeIdentifier(ctx types.Context, identifier string) {
+	value, err := ctx.Dialect().QuoteIdentifier(identifier)
+	if err != nil {
+		ctx.Fail(err)
+		return
+	}
+	ctx.Write(value)
+}
+
+// writeAlias writes given alias, quoted using context's dialect.
+func writeAlias(ctx types.Context, alias string) {
+	value, err := ctx.Dialect().QuoteAlias(alias)
+	if err != nil {
+		ctx.Fail(err)
+		return
+	}
+	ctx.Write(value)
+}
+
+// requireFeature reports an error on context if its dialect doesn't support given feature.
+func requireFeature(ctx types.Context, feature types.Feature) {
+	dialect := ctx.Dialect()
+	if !dialect.Supports(feature) {
+		ctx.Fail(types.NewErrorf(types.ErrUnsupportedFeature, "%s dialect doesn't support %s", dialect.Name(), feature))
+	}
+}
+
+// reportErrors reports given errors on context, such as errors of the builder of a subquery.
+func reportErrors(ctx types.Context, errs types.Errors) {
+	for i := range errs {
+		ctx.Fail(errs[i])
+	}
+}
+
+// writeForColumn writes given expression, whose arguments are named after given column by contexts that support it.
+func writeForColumn(ctx types.Context, column string, expression Expression) {
+	columnCtx, ok := ctx.(types.ColumnContext)
+	if !ok {
+		expression.Write(ctx)
+		return
+	}
+
+	previous := columnCtx.SetColumn(column)
+	expression.Write(ctx)
+	columnCtx.SetColumn(previous)
+}
@@ -0,0 +1,71 @@
+package stmt
+
+import (
+	"github.com/ulule/loukoum/v3/token"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// DistinctOn is a DISTINCT ON clause.
+type DistinctOn struct {
+	Expressions []Expression
+}
+
+// NewDistinctOn returns a new DistinctOn instance.
+func NewDistinctOn(expressions []Expression) DistinctOn {
+	return DistinctOn{
+		Expressions: expressions,
+	}
+}
+
+// Write exposes statement as a SQL query.
+func (distinct DistinctOn) Write(ctx types.Context) {
+	requireFeature(ctx, types.FeatureDistinctOn)
+
+	ctx.Write(token.Distinct.String())
+	ctx.Write(" ")
+	ctx.Write(token.On.String())
+	ctx.Write(" (")
+	for i := range distinct.Expressions {
+		if i != 0 {
+			ctx.Write(", ")
+		}
+		distinct.Expressions[i].Write(ctx)
+	}
+	ctx.Write(")")
+}
+
+// IsEmpty returns true if statement is undefined.
+func (distinct DistinctOn) IsEmpty() bool {
+	return len(distinct.Expressions) == 0
+}
+
+// matches returns true if the leading expressions of given ORDER BY clause are DISTINCT ON expressions,
+// as required by PostgreSQL.
+func (distinct DistinctOn) matches(dialect types.Dialect, order OrderBy) bool {
+	keys := make(map[string]bool, len(distinct.Expressions))
+	for i := range distinct.Expressions {
+		keys[toKey(dialect, distinct.Expressions[i])] = true
+	}
+
+	for i := 0; i < len(order.Orders) && i < len(distinct.Expressions); i++ {
+		value := order.Orders[i].Value
+		if value == nil {
+			value = NewIdentifier(order.Orders[i].Expression)
+		}
+		if !keys[toKey(dialect, value)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// toKey returns given expression as a raw statement, so it can be compared with another expression.
+func toKey(dialect types.Dialect, expression Expression) string {
+	ctx := types.NewRawContext(dialect)
+	expression.Write(ctx)
+	return ctx.Query()
+}
+
+// Ensure that DistinctOn is a Statement
+var _ Statement = DistinctOn{}
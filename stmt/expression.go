@@ -2,7 +2,6 @@ package stmt
 
 import (
 	"database/sql/driver"
-	"fmt"
 	"time"
 
 	"github.com/ulule/loukoum/v3/types"
@@ -35,7 +34,7 @@ func NewExpression(arg interface{}) Expression { // nolint: gocyclo
 		stmt := value.Statement()
 		expression, ok := stmt.(Expression)
 		if !ok {
-			panic(fmt.Sprintf("cannot use {%+v}[%T] as loukoum Expression", value, value))
+			panic(types.NewErrorf(types.ErrInvalidExpression, "cannot use {%+v}[%T] as loukoum Expression", value, value))
 		}
 		return expression
 	case Int64Encoder:
@@ -47,7 +46,7 @@ func NewExpression(arg interface{}) Expression { // nolint: gocyclo
 	case StringEncoder:
 		return NewValue(value.String())
 	default:
-		panic(fmt.Sprintf("cannot use {%+v}[%T] as loukoum Expression", arg, arg))
+		panic(types.NewErrorf(types.ErrInvalidExpression, "cannot use {%+v}[%T] as loukoum Expression", arg, arg))
 	}
 }
 
@@ -71,7 +70,7 @@ func (Identifier) expression() {}
 
 // Write exposes statement as a SQL query.
 func (identifier Identifier) Write(ctx types.Context) {
-	ctx.Write(identifier.Identifier)
+	writeIdentifier(ctx, identifier.Identifier)
 }
 
 // IsEmpty returns true if statement is undefined.
@@ -81,26 +80,22 @@ func (identifier Identifier) IsEmpty() bool {
 
 // Equal performs an "equal" comparison.
 func (identifier Identifier) Equal(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.Equal)
-	return NewInfixExpression(identifier, operator, NewWrapper(NewExpression(value)))
+	return compare(identifier, types.Equal, value)
 }
 
 // NotEqual performs a "not equal" comparison.
 func (identifier Identifier) NotEqual(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.NotEqual)
-	return NewInfixExpression(identifier, operator, NewWrapper(NewExpression(value)))
+	return compare(identifier, types.NotEqual, value)
 }
 
 // Is performs a "is" comparison.
 func (identifier Identifier) Is(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.Is)
-	return NewInfixExpression(identifier, operator, NewExpression(value))
+	return match(identifier, types.Is, value)
 }
 
 // IsNot performs a "is not" comparison.
 func (identifier Identifier) IsNot(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.IsNot)
-	return NewInfixExpression(identifier, operator, NewExpression(value))
+	return match(identifier, types.IsNot, value)
 }
 
 // IsNull performs a "is null" comparison.
@@ -113,26 +108,22 @@ func (identifier Identifier) IsNull(value bool) InfixExpression {
 
 // GreaterThan performs a "greater than" comparison.
 func (identifier Identifier) GreaterThan(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.GreaterThan)
-	return NewInfixExpression(identifier, operator, NewWrapper(NewExpression(value)))
+	return compare(identifier, types.GreaterThan, value)
 }
 
 // GreaterThanOrEqual performs a "greater than or equal to" comparison.
 func (identifier Identifier) GreaterThanOrEqual(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.GreaterThanOrEqual)
-	return NewInfixExpression(identifier, operator, NewWrapper(NewExpression(value)))
+	return compare(identifier, types.GreaterThanOrEqual, value)
 }
 
 // LessThan performs a "less than" comparison.
 func (identifier Identifier) LessThan(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.LessThan)
-	return NewInfixExpression(identifier, operator, NewWrapper(NewExpression(value)))
+	return compare(identifier, types.LessThan, value)
 }
 
 // LessThanOrEqual performs a "less than or equal to" comparison.
 func (identifier Identifier) LessThanOrEqual(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.LessThanOrEqual)
-	return NewInfixExpression(identifier, operator, NewWrapper(NewExpression(value)))
+	return compare(identifier, types.LessThanOrEqual, value)
 }
 
 // In performs a "in" condition.
@@ -147,26 +138,22 @@ func (identifier Identifier) NotIn(value ...interface{}) In {
 
 // Like performs a "like" condition.
 func (identifier Identifier) Like(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.Like)
-	return NewInfixExpression(identifier, operator, NewExpression(value))
+	return match(identifier, types.Like, value)
 }
 
 // NotLike performs a "not like" condition.
 func (identifier Identifier) NotLike(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.NotLike)
-	return NewInfixExpression(identifier, operator, NewExpression(value))
+	return match(identifier, types.NotLike, value)
 }
 
 // ILike performs a "ilike" condition.
 func (identifier Identifier) ILike(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.ILike)
-	return NewInfixExpression(identifier, operator, NewExpression(value))
+	return match(identifier, types.ILike, value)
 }
 
 // NotILike performs a "not ilike" condition.
 func (identifier Identifier) NotILike(value interface{}) InfixExpression {
-	operator := NewComparisonOperator(types.NotILike)
-	return NewInfixExpression(identifier, operator, NewExpression(value))
+	return match(identifier, types.NotILike, value)
 }
 
 // Between performs a "between" condition.
@@ -179,6 +166,56 @@ func (identifier Identifier) NotBetween(from, to interface{}) Between {
 	return NewNotBetween(identifier, NewExpression(from), NewExpression(to))
 }
 
+// Add performs an addition.
+func (identifier Identifier) Add(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.Add, value)
+}
+
+// Subtract performs a subtraction.
+func (identifier Identifier) Subtract(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.Subtract, value)
+}
+
+// Multiply performs a multiplication.
+func (identifier Identifier) Multiply(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.Multiply, value)
+}
+
+// Divide performs a division.
+func (identifier Identifier) Divide(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.Divide, value)
+}
+
+// Modulo performs a modulo.
+func (identifier Identifier) Modulo(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.Modulo, value)
+}
+
+// Concat performs a string concatenation.
+func (identifier Identifier) Concat(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.Concat, value)
+}
+
+// BitwiseAnd performs a bitwise "and".
+func (identifier Identifier) BitwiseAnd(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.BitwiseAnd, value)
+}
+
+// BitwiseOr performs a bitwise "or".
+func (identifier Identifier) BitwiseOr(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.BitwiseOr, value)
+}
+
+// BitwiseShiftLeft performs a bitwise shift left.
+func (identifier Identifier) BitwiseShiftLeft(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.BitwiseShiftLeft, value)
+}
+
+// BitwiseShiftRight performs a bitwise shift right.
+func (identifier Identifier) BitwiseShiftRight(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(identifier, types.BitwiseShiftRight, value)
+}
+
 // Ensure that Identifier is an Expression
 var _ Expression = Identifier{}
 
@@ -217,6 +254,43 @@ func (value Value) IsEmpty() bool {
 // Ensure that Value is an Expression
 var _ Expression = Value{}
 
+// ----------------------------------------------------------------------------
+// Param
+// ----------------------------------------------------------------------------
+
+// Param is a named parameter, whose value is bound when the query is executed.
+type Param struct {
+	Name string
+}
+
+// NewParam returns a named parameter.
+func NewParam(name string) Param {
+	return Param{
+		Name: name,
+	}
+}
+
+func (Param) expression() {}
+
+// Write exposes statement as a SQL query.
+func (param Param) Write(ctx types.Context) {
+	value := types.Param{Name: param.Name}
+	if !value.IsValid() {
+		ctx.Fail(types.NewErrorf(types.ErrInvalidParameter,
+			"parameter name %q must match [A-Za-z_][A-Za-z0-9_]*", param.Name))
+		return
+	}
+	ctx.Bind(value)
+}
+
+// IsEmpty returns true if statement is undefined.
+func (param Param) IsEmpty() bool {
+	return param.Name == ""
+}
+
+// Ensure that Param is an Expression
+var _ Expression = Param{}
+
 // ----------------------------------------------------------------------------
 // Array
 // ----------------------------------------------------------------------------
@@ -390,6 +464,10 @@ func NewWrapper(arg Expression) Expression {
 		return &Wrapper{
 			Value: value,
 		}
+	case Compound:
+		return &Wrapper{
+			Value: value,
+		}
 	case Exists:
 		return &Wrapper{
 			Value: value,
@@ -226,3 +226,23 @@ type ttencoder struct {
 func (e ttencoder) Time() time.Time {
 	return e.value
 }
+
+func TestArithmeticExpression_Alias(t *testing.T) {
+	is := require.New(t)
+
+	// Operands are written without their alias...
+	expression := stmt.NewArithmeticExpression(
+		stmt.NewFunc("length", stmt.NewColumn("name")).As("size"),
+		stmt.NewArithmeticOperator(types.Add),
+		stmt.NewUnaryExpression(types.Negate, stmt.NewIdentifier("offset")).As("shift"),
+	)
+
+	ctx := types.NewRawContext(types.PostgreSQL)
+	expression.Write(ctx)
+	is.Equal("(length(name) + (-offset))", ctx.Query())
+
+	// ...unlike the expression itself.
+	ctx = types.NewRawContext(types.PostgreSQL)
+	expression.As("total").Write(ctx)
+	is.Equal("(length(name) + (-offset)) AS total", ctx.Query())
+}
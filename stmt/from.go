@@ -23,6 +23,7 @@ func NewFrom(table Table, only bool) From {
 func (from From) Write(ctx types.Context) {
 	ctx.Write(token.From.String())
 	if from.Only {
+		requireFeature(ctx, types.FeatureOnly)
 		ctx.Write(" ")
 		ctx.Write(token.Only.String())
 	}
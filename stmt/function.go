@@ -0,0 +1,241 @@
+package stmt
+
+import (
+	"github.com/ulule/loukoum/v3/token"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// Func is a function call expression, such as "lower(email)" or "coalesce(nickname, username)".
+type Func struct {
+	Name   string
+	Args   []Expression
+	Window *Window
+	Alias  string
+}
+
+// NewFunc returns a new Func instance.
+// Arguments are either Column instances, Expression instances or values that are bound to the query.
+func NewFunc(name string, args ...interface{}) Func {
+	function := Func{
+		Name: name,
+		Args: make([]Expression, 0, len(args)),
+	}
+	for i := range args {
+		function.Args = append(function.Args, NewOperand(args[i]))
+	}
+	return function
+}
+
+// NewOperand returns a new Expression instance from arg, which can be used as an operand.
+// Unlike NewExpression, a Column is used as an Identifier, a subquery is wrapped with parenthesis, and the alias
+// of an expression is dropped.
+func NewOperand(arg interface{}) Expression {
+	column, ok := arg.(Column)
+	if ok {
+		return NewIdentifier(column.Name)
+	}
+	return NewWrapper(withoutAlias(NewExpression(arg)))
+}
+
+func (Func) expression() {}
+
+func (Func) selectExpression() {}
+
+// Write exposes statement as a SQL query.
+func (function Func) Write(ctx types.Context) {
+	if !isFunctionName(function.Name) {
+		ctx.Fail(types.NewErrorf(types.ErrInvalidExpression, "%q is not a valid function name", function.Name))
+		return
+	}
+
+	ctx.Write(function.Name)
+	ctx.Write("(")
+	for i := range function.Args {
+		if i > 0 {
+			ctx.Write(", ")
+		}
+		function.Args[i].Write(ctx)
+	}
+	ctx.Write(")")
+	writeOver(ctx, function.Window)
+
+	if function.Alias != "" {
+		ctx.Write(" ")
+		ctx.Write(token.As.String())
+		ctx.Write(" ")
+		writeAlias(ctx, function.Alias)
+	}
+}
+
+// IsEmpty returns true if statement is undefined.
+func (function Func) IsEmpty() bool {
+	return function.Name == ""
+}
+
+// As is used to give an alias name to the function result.
+func (function Func) As(alias string) Func {
+	function.Alias = alias
+	return function
+}
+
+// Over defines the window of the function, which makes it a window function.
+func (function Func) Over(window Window) Func {
+	function.Window = &window
+	return function
+}
+
+// Asc is used to transform a function to an order expression.
+func (function Func) Asc() Order {
+	if function.Alias != "" {
+		return NewOrder(function.Alias, types.Asc)
+	}
+	return NewOrderExpression(function, types.Asc)
+}
+
+// Desc is used to transform a function to an order expression.
+func (function Func) Desc() Order {
+	if function.Alias != "" {
+		return NewOrder(function.Alias, types.Desc)
+	}
+	return NewOrderExpression(function, types.Desc)
+}
+
+// Equal performs an "equal" comparison.
+func (function Func) Equal(value interface{}) InfixExpression {
+	return compare(function, types.Equal, value)
+}
+
+// NotEqual performs a "not equal" comparison.
+func (function Func) NotEqual(value interface{}) InfixExpression {
+	return compare(function, types.NotEqual, value)
+}
+
+// Is performs a "is" comparison.
+func (function Func) Is(value interface{}) InfixExpression {
+	return match(function, types.Is, value)
+}
+
+// IsNot performs a "is not" comparison.
+func (function Func) IsNot(value interface{}) InfixExpression {
+	return match(function, types.IsNot, value)
+}
+
+// IsNull performs a "is null" comparison.
+func (function Func) IsNull(value bool) InfixExpression {
+	if value {
+		return function.Is(nil)
+	}
+	return function.IsNot(nil)
+}
+
+// GreaterThan performs a "greater than" comparison.
+func (function Func) GreaterThan(value interface{}) InfixExpression {
+	return compare(function, types.GreaterThan, value)
+}
+
+// GreaterThanOrEqual performs a "greater than or equal to" comparison.
+func (function Func) GreaterThanOrEqual(value interface{}) InfixExpression {
+	return compare(function, types.GreaterThanOrEqual, value)
+}
+
+// LessThan performs a "less than" comparison.
+func (function Func) LessThan(value interface{}) InfixExpression {
+	return compare(function, types.LessThan, value)
+}
+
+// LessThanOrEqual performs a "less than or equal to" comparison.
+func (function Func) LessThanOrEqual(value interface{}) InfixExpression {
+	return compare(function, types.LessThanOrEqual, value)
+}
+
+// Like performs a "like" condition.
+func (function Func) Like(value interface{}) InfixExpression {
+	return match(function, types.Like, value)
+}
+
+// NotLike performs a "not like" condition.
+func (function Func) NotLike(value interface{}) InfixExpression {
+	return match(function, types.NotLike, value)
+}
+
+// ILike performs a "ilike" condition.
+func (function Func) ILike(value interface{}) InfixExpression {
+	return match(function, types.ILike, value)
+}
+
+// NotILike performs a "not ilike" condition.
+func (function Func) NotILike(value interface{}) InfixExpression {
+	return match(function, types.NotILike, value)
+}
+
+// Add performs an addition.
+func (function Func) Add(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.Add, value)
+}
+
+// Subtract performs a subtraction.
+func (function Func) Subtract(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.Subtract, value)
+}
+
+// Multiply performs a multiplication.
+func (function Func) Multiply(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.Multiply, value)
+}
+
+// Divide performs a division.
+func (function Func) Divide(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.Divide, value)
+}
+
+// Modulo performs a modulo.
+func (function Func) Modulo(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.Modulo, value)
+}
+
+// Concat performs a string concatenation.
+func (function Func) Concat(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.Concat, value)
+}
+
+// BitwiseAnd performs a bitwise "and".
+func (function Func) BitwiseAnd(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.BitwiseAnd, value)
+}
+
+// BitwiseOr performs a bitwise "or".
+func (function Func) BitwiseOr(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.BitwiseOr, value)
+}
+
+// BitwiseShiftLeft performs a bitwise shift left.
+func (function Func) BitwiseShiftLeft(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.BitwiseShiftLeft, value)
+}
+
+// BitwiseShiftRight performs a bitwise shift right.
+func (function Func) BitwiseShiftRight(value interface{}) ArithmeticExpression {
+	return newArithmeticExpression(function, types.BitwiseShiftRight, value)
+}
+
+// isFunctionName returns true if given name is a function name, optionally qualified by a schema.
+func isFunctionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, char := range name {
+		switch {
+		case char == '_', 'a' <= char && char <= 'z', 'A' <= char && char <= 'Z':
+		case i > 0 && (char == '.' || '0' <= char && char <= '9'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Ensure that Func is an Expression
+var _ Expression = Func{}
+
+// Ensure that Func is a SelectExpression
+var _ SelectExpression = Func{}
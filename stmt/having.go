@@ -21,7 +21,8 @@ func NewHaving(expression Expression) Having {
 // Write exposes statement as a SQL query.
 func (having Having) Write(ctx types.Context) {
 	if having.IsEmpty() {
-		panic("loukoum: a having clause expects at least one condition")
+		ctx.Fail(types.NewError(types.ErrInvalidCondition, "a having clause expects at least one condition"))
+		return
 	}
 
 	ctx.Write(token.Having.String())
@@ -37,7 +38,7 @@ func (having Having) IsEmpty() bool {
 // And appends given Expression using AND as logical operator.
 func (having Having) And(right Expression) Having {
 	if having.IsEmpty() {
-		panic("loukoum: two conditions are required for AND statement")
+		panic(types.NewError(types.ErrInvalidCondition, "two conditions are required for AND statement"))
 	}
 
 	left := having.Condition
@@ -49,7 +50,7 @@ func (having Having) And(right Expression) Having {
 // Or appends given Expression using OR as logical operator.
 func (having Having) Or(right Expression) Having {
 	if having.IsEmpty() {
-		panic("loukoum: two conditions are required for OR statement")
+		panic(types.NewError(types.ErrInvalidCondition, "two conditions are required for OR statement"))
 	}
 
 	left := having.Condition
@@ -34,7 +34,8 @@ func (In) expression() {}
 // Write exposes statement as a SQL query.
 func (in In) Write(ctx types.Context) {
 	if in.IsEmpty() {
-		panic("loukoum: expression is undefined")
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "expression is undefined"))
+		return
 	}
 
 	ctx.Write("(")
@@ -43,7 +44,7 @@ func (in In) Write(ctx types.Context) {
 	in.Operator.Write(ctx)
 	ctx.Write(" (")
 	if !in.Value.IsEmpty() {
-		in.Value.Write(ctx)
+		writeForColumn(ctx, in.Identifier.Identifier, in.Value)
 	}
 	ctx.Write("))")
 }
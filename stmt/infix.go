@@ -26,7 +26,8 @@ func (InfixExpression) expression() {}
 // Write exposes statement as a SQL query.
 func (expression InfixExpression) Write(ctx types.Context) {
 	if expression.IsEmpty() {
-		panic("loukoum: expression is undefined")
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "expression is undefined"))
+		return
 	}
 
 	ctx.Write("(")
@@ -34,7 +35,12 @@ func (expression InfixExpression) Write(ctx types.Context) {
 	ctx.Write(" ")
 	expression.Operator.Write(ctx)
 	ctx.Write(" ")
-	expression.Right.Write(ctx)
+	identifier, ok := expression.Left.(Identifier)
+	if ok {
+		writeForColumn(ctx, identifier.Identifier, expression.Right)
+	} else {
+		expression.Right.Write(ctx)
+	}
 	ctx.Write(")")
 }
 
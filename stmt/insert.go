@@ -1,17 +1,23 @@
 package stmt
 
 import (
+	"strings"
+
 	"github.com/ulule/loukoum/v3/token"
 	"github.com/ulule/loukoum/v3/types"
 )
 
 // Insert is a INSERT statement.
 type Insert struct {
+	With       With
 	Into       Into
 	Columns    []Column
 	Values     Values
+	Query      Expression
 	OnConflict OnConflict
 	Returning  Returning
+	// Errors are reported by the builder of the statement, if it's embedded in another one, such as a subquery.
+	Errors types.Errors
 }
 
 // NewInsert returns a new Insert instance.
@@ -21,8 +27,26 @@ func NewInsert() Insert {
 
 // Write exposes statement as a SQL query.
 func (insert Insert) Write(ctx types.Context) {
+	reportErrors(ctx, insert.Errors)
 	if insert.IsEmpty() {
-		panic("loukoum: an insert statement must have at least one column")
+		ctx.Fail(types.NewError(types.ErrEmptyStatement, "an insert statement must have at least one column"))
+		return
+	}
+	if !insert.hasValidValues() {
+		ctx.Fail(types.NewError(types.ErrInvalidColumn,
+			"insert statement rows must have the same number of values as columns"))
+		return
+	}
+	if !insert.hasValidQuery() {
+		ctx.Fail(types.NewErrorf(types.ErrInvalidColumn,
+			"insert statement has %d columns but its query returns %d", len(insert.Columns), selectArity(insert.Query)))
+		return
+	}
+
+	if !insert.With.IsEmpty() {
+		requireFeature(ctx, types.FeatureInsertWith)
+		insert.With.Write(ctx)
+		ctx.Write(" ")
 	}
 
 	ctx.Write(token.Insert.String())
@@ -42,7 +66,12 @@ func (insert Insert) Write(ctx types.Context) {
 
 	if !insert.Values.IsEmpty() {
 		ctx.Write(" ")
-		insert.Values.Write(ctx)
+		insert.Values.write(ctx, insert.Columns)
+	}
+
+	if insert.Query != nil {
+		ctx.Write(" ")
+		insert.Query.Write(ctx)
 	}
 
 	if !insert.OnConflict.IsEmpty() {
@@ -61,5 +90,44 @@ func (insert Insert) IsEmpty() bool {
 	return insert.Into.IsEmpty()
 }
 
+// hasValidValues returns false if rows don't have the same number of values, or if this number doesn't match
+// the number of columns, when it's known.
+func (insert Insert) hasValidValues() bool {
+	if insert.Values.IsEmpty() {
+		// Rows can't be written if one of them is undefined.
+		return len(insert.Values.Rows) == 0
+	}
+	arity := insert.Values.arity()
+	return arity >= 0 && (len(insert.Columns) == 0 || arity == len(insert.Columns))
+}
+
+// hasValidQuery returns false if the number of columns doesn't match the result columns of the query,
+// when both are known.
+func (insert Insert) hasValidQuery() bool {
+	if insert.Query == nil || len(insert.Columns) == 0 {
+		return true
+	}
+	arity := selectArity(insert.Query)
+	return arity < 0 || arity == len(insert.Columns)
+}
+
+// selectArity returns the number of result columns of given query, or -1 if it's unknown.
+func selectArity(query Expression) int {
+	switch value := query.(type) {
+	case Select:
+		for i := range value.Expressions {
+			column, ok := value.Expressions[i].(Column)
+			if ok && (column.Name == "*" || strings.HasSuffix(column.Name, ".*")) {
+				return -1
+			}
+		}
+		return len(value.Expressions)
+	case Compound:
+		return selectArity(value.Left)
+	default:
+		return -1
+	}
+}
+
 // Ensure that Insert is a Statement
 var _ Statement = Insert{}
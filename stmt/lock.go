@@ -0,0 +1,104 @@
+package stmt
+
+import (
+	"strings"
+
+	"github.com/ulule/loukoum/v3/token"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// Lock is a row-level locking clause, such as "FOR UPDATE".
+type Lock struct {
+	Strength types.LockStrength
+	Tables   []Table
+	Wait     types.LockWait
+}
+
+// NewLock returns a new Lock instance.
+func NewLock(strength types.LockStrength, tables []Table) Lock {
+	return Lock{
+		Strength: strength,
+		Tables:   tables,
+	}
+}
+
+// Write exposes statement as a SQL query.
+func (lock Lock) Write(ctx types.Context) {
+	requireFeature(ctx, types.FeatureLocking)
+	if lock.Strength == types.ForNoKeyUpdate || lock.Strength == types.ForKeyShare {
+		requireFeature(ctx, types.FeatureKeyLocking)
+	}
+
+	ctx.Write(lock.Strength.String())
+
+	for i := range lock.Tables {
+		if i == 0 {
+			ctx.Write(" ")
+			ctx.Write(token.Of.String())
+			ctx.Write(" ")
+		} else {
+			ctx.Write(", ")
+		}
+		// An aliased table must be referenced by its alias.
+		if lock.Tables[i].Alias != "" {
+			writeAlias(ctx, lock.Tables[i].Alias)
+		} else {
+			writeIdentifier(ctx, lock.Tables[i].Name)
+		}
+	}
+
+	if lock.Wait != "" {
+		ctx.Write(" ")
+		ctx.Write(lock.Wait.String())
+	}
+}
+
+// IsEmpty returns true if statement is undefined.
+func (lock Lock) IsEmpty() bool {
+	return lock.Strength == ""
+}
+
+// aggregateNames are the names of common aggregate functions, in lower case.
+var aggregateNames = map[string]bool{
+	"array_agg": true, "avg": true, "bit_and": true, "bit_or": true, "bool_and": true, "bool_or": true,
+	"count": true, "every": true, "group_concat": true, "json_agg": true, "json_object_agg": true,
+	"jsonb_agg": true, "jsonb_object_agg": true, "max": true, "min": true, "stddev": true, "string_agg": true,
+	"sum": true, "variance": true, "xmlagg": true,
+}
+
+// isAggregate returns true if given expression uses an aggregate or a window function, outside of a subquery.
+func isAggregate(expression Statement) bool { // nolint: gocyclo
+	switch value := expression.(type) {
+	case Count, Max, Min, Sum:
+		return true
+	case Func:
+		if value.Window != nil || aggregateNames[strings.ToLower(value.Name)] {
+			return true
+		}
+		for i := range value.Args {
+			if isAggregate(value.Args[i]) {
+				return true
+			}
+		}
+		return false
+	case ArithmeticExpression:
+		return isAggregate(value.Left) || isAggregate(value.Right)
+	case UnaryExpression:
+		return isAggregate(value.Value)
+	case Case:
+		if isAggregate(value.Value) || isAggregate(value.Default) {
+			return true
+		}
+		for i := range value.Whens {
+			if isAggregate(value.Whens[i].Condition) || isAggregate(value.Whens[i].Result) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// Ensure that Lock is a Statement
+var _ Statement = Lock{}
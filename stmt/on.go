@@ -43,11 +43,11 @@ func (on OnClause) Or(value OnExpression) OnExpression {
 
 // Write exposes statement as a SQL query.
 func (on OnClause) Write(ctx types.Context) {
-	ctx.Write(on.Left.Name)
+	writeIdentifier(ctx, on.Left.Name)
 	ctx.Write(" ")
 	ctx.Write(token.Equals.String())
 	ctx.Write(" ")
-	ctx.Write(on.Right.Name)
+	writeIdentifier(ctx, on.Right.Name)
 }
 
 // IsEmpty returns true if statement is undefined.
@@ -80,7 +80,8 @@ func (InfixOnExpression) onExpression() {}
 // Write exposes statement as a SQL query.
 func (expression InfixOnExpression) Write(ctx types.Context) {
 	if expression.IsEmpty() {
-		panic("loukoum: expression is undefined")
+		ctx.Fail(types.NewError(types.ErrInvalidExpression, "expression is undefined"))
+		return
 	}
 
 	ctx.Write("(")
@@ -0,0 +1,36 @@
+package stmt
+
+import (
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// compare returns a comparison of given operand with a value, which is wrapped with parenthesis if it's a subquery.
+func compare(left Expression, operator types.ComparisonOperator, value interface{}) InfixExpression {
+	return NewInfixExpression(withoutAlias(left), NewComparisonOperator(operator), NewWrapper(NewExpression(value)))
+}
+
+// match returns a comparison of given operand with a value that is never wrapped, such as "IS NULL" or "LIKE".
+func match(left Expression, operator types.ComparisonOperator, value interface{}) InfixExpression {
+	return NewInfixExpression(withoutAlias(left), NewComparisonOperator(operator), NewExpression(value))
+}
+
+// withoutAlias returns given expression without its alias, since an alias cannot be written inside another
+// expression, such as "(price * quantity AS total) + 1".
+func withoutAlias(expression Expression) Expression {
+	switch value := expression.(type) {
+	case ArithmeticExpression:
+		value.Alias = ""
+		return value
+	case UnaryExpression:
+		value.Alias = ""
+		return value
+	case Func:
+		value.Alias = ""
+		return value
+	case Case:
+		value.Alias = ""
+		return value
+	default:
+		return expression
+	}
+}
@@ -7,6 +7,7 @@ import (
 // Order is an expression of a ORDER BY clause.
 type Order struct {
 	Expression string
+	Value      Expression
 	Type       types.OrderType
 }
 
@@ -18,19 +19,31 @@ func NewOrder(expression string, kind types.OrderType) Order {
 	}
 }
 
+// NewOrderExpression returns a new Order instance using an expression, such as a function call.
+func NewOrderExpression(value Expression, kind types.OrderType) Order {
+	return Order{
+		Value: value,
+		Type:  kind,
+	}
+}
+
 // Write exposes statement as a SQL query.
 func (order Order) Write(ctx types.Context) {
 	if order.IsEmpty() {
 		return
 	}
-	ctx.Write(order.Expression)
+	if order.Value != nil {
+		order.Value.Write(ctx)
+	} else {
+		writeIdentifier(ctx, order.Expression)
+	}
 	ctx.Write(" ")
 	ctx.Write(order.Type.String())
 }
 
 // IsEmpty returns true if statement is undefined.
 func (order Order) IsEmpty() bool {
-	return order.Expression == ""
+	return order.Expression == "" && order.Value == nil
 }
 
 // Ensure that Order is a Statement
@@ -1,15 +1,15 @@
 package stmt
 
 import (
-	"sort"
-
 	"github.com/ulule/loukoum/v3/token"
 	"github.com/ulule/loukoum/v3/types"
 )
 
 // Returning is a RETURNING clause.
+// Columns, then computed values, are written in their given order, so that rows can be scanned by position.
 type Returning struct {
-	Columns []Column
+	Columns     []Column
+	Expressions []SelectExpression
 }
 
 // NewReturning returns a new Returning instance.
@@ -19,27 +19,37 @@ func NewReturning(columns []Column) Returning {
 	}
 }
 
+// NewReturningExpressions returns a new Returning instance using given expressions, such as columns and functions.
+func NewReturningExpressions(expressions []SelectExpression) Returning {
+	return Returning{
+		Expressions: expressions,
+	}
+}
+
 // Write exposes statement as a SQL query.
 func (returning Returning) Write(ctx types.Context) {
+	requireFeature(ctx, types.FeatureReturning)
+
 	ctx.Write(token.Returning.String())
 	ctx.Write(" ")
 
-	sort.Slice(returning.Columns, func(i, j int) bool {
-		return returning.Columns[i].Name < returning.Columns[j].Name ||
-			returning.Columns[i].Alias < returning.Columns[j].Alias
-	})
-
 	for i := range returning.Columns {
 		if i > 0 {
 			ctx.Write(", ")
 		}
 		returning.Columns[i].Write(ctx)
 	}
+	for i := range returning.Expressions {
+		if i > 0 || len(returning.Columns) > 0 {
+			ctx.Write(", ")
+		}
+		returning.Expressions[i].Write(ctx)
+	}
 }
 
 // IsEmpty returns true if statement is undefined.
 func (returning Returning) IsEmpty() bool {
-	return len(returning.Columns) == 0
+	return len(returning.Columns) == 0 && len(returning.Expressions) == 0
 }
 
 // Ensure that Returning is a Statement
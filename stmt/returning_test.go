@@ -0,0 +1,39 @@
+package stmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3/stmt"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+func TestReturning(t *testing.T) {
+	is := require.New(t)
+
+	// Columns are written in their given order...
+	{
+		returning := stmt.NewReturning([]stmt.Column{stmt.NewColumn("id"), stmt.NewColumn("created_at")})
+
+		ctx := types.NewRawContext(types.PostgreSQL)
+		returning.Write(ctx)
+		is.Equal("RETURNING id, created_at", ctx.Query())
+	}
+
+	// ...followed by expressions.
+	{
+		returning := stmt.NewReturning([]stmt.Column{stmt.NewColumn("id")})
+		returning.Expressions = []stmt.SelectExpression{
+			stmt.NewFunc("lower", stmt.NewColumn("email")).As("email"),
+		}
+
+		ctx := types.NewRawContext(types.PostgreSQL)
+		returning.Write(ctx)
+		is.Equal("RETURNING id, lower(email) AS email", ctx.Query())
+	}
+	{
+		is.True(stmt.Returning{}.IsEmpty())
+		is.False(stmt.NewReturningExpressions([]stmt.SelectExpression{stmt.NewColumn("id")}).IsEmpty())
+	}
+}
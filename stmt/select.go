@@ -16,16 +16,21 @@ type Select struct {
 	Prefix      Prefix
 	With        With
 	Distinct    bool
+	DistinctOn  DistinctOn
 	Expressions []SelectExpression
 	From        From
 	Joins       []Join
 	Where       Where
 	GroupBy     GroupBy
 	Having      Having
+	Window      WindowClause
 	OrderBy     OrderBy
 	Limit       Limit
 	Offset      Offset
+	Locks       []Lock
 	Suffix      Suffix
+	// Errors are reported by the builder of the statement, if it's embedded in another one, such as a subquery.
+	Errors types.Errors
 }
 
 // NewSelect returns a new Select instance.
@@ -35,8 +40,22 @@ func NewSelect() Select {
 
 // Write exposes statement as a SQL query.
 func (selekt Select) Write(ctx types.Context) {
+	reportErrors(ctx, selekt.Errors)
 	if selekt.IsEmpty() {
-		panic("loukoum: select statements must have at least one column")
+		ctx.Fail(types.NewError(types.ErrEmptyStatement, "select statements must have at least one column"))
+		return
+	}
+	if !selekt.DistinctOn.IsEmpty() && !selekt.DistinctOn.matches(ctx.Dialect(), selekt.OrderBy) {
+		ctx.Fail(types.NewError(types.ErrInvalidClause,
+			"DISTINCT ON expressions must match the leading ORDER BY expressions"))
+		return
+	}
+
+	// Windows of an outer query cannot be referenced by a subquery.
+	windowCtx, ok := ctx.(types.WindowContext)
+	if ok {
+		previous := windowCtx.SetWindows(selekt.Window.Names())
+		defer windowCtx.SetWindows(previous)
 	}
 
 	selekt.writeHead(ctx)
@@ -57,7 +76,10 @@ func (selekt Select) writeHead(ctx types.Context) {
 
 	ctx.Write(token.Select.String())
 
-	if selekt.Distinct {
+	if !selekt.DistinctOn.IsEmpty() {
+		ctx.Write(" ")
+		selekt.DistinctOn.Write(ctx)
+	} else if selekt.Distinct {
 		ctx.Write(" ")
 		ctx.Write(token.Distinct.String())
 	}
@@ -97,6 +119,11 @@ func (selekt Select) writeMiddle(ctx types.Context) {
 		ctx.Write(" ")
 		selekt.Having.Write(ctx)
 	}
+
+	if !selekt.Window.IsEmpty() {
+		ctx.Write(" ")
+		selekt.Window.Write(ctx)
+	}
 }
 
 func (selekt Select) writeTail(ctx types.Context) {
@@ -115,12 +142,55 @@ func (selekt Select) writeTail(ctx types.Context) {
 		selekt.Offset.Write(ctx)
 	}
 
+	if len(selekt.Locks) > 0 {
+		selekt.writeLocks(ctx)
+	}
+
 	if !selekt.Suffix.IsEmpty() {
 		ctx.Write(" ")
 		selekt.Suffix.Write(ctx)
 	}
 }
 
+func (selekt Select) writeLocks(ctx types.Context) {
+	clause := ""
+	switch {
+	case selekt.Distinct || !selekt.DistinctOn.IsEmpty():
+		clause = token.Distinct.String()
+	case !selekt.GroupBy.IsEmpty():
+		clause = "GROUP BY"
+	case !selekt.Having.IsEmpty():
+		clause = token.Having.String()
+	case !selekt.Window.IsEmpty():
+		clause = token.Window.String()
+	}
+	if clause != "" {
+		ctx.Fail(types.NewErrorf(types.ErrInvalidClause, "%s is not allowed with %s clause",
+			selekt.Locks[0].Strength, clause))
+		return
+	}
+	if selekt.hasAggregate() {
+		ctx.Fail(types.NewErrorf(types.ErrInvalidClause, "%s is not allowed with aggregate or window functions",
+			selekt.Locks[0].Strength))
+		return
+	}
+
+	for i := range selekt.Locks {
+		ctx.Write(" ")
+		selekt.Locks[i].Write(ctx)
+	}
+}
+
+// hasAggregate returns true if an expression of the select list uses an aggregate or a window function.
+func (selekt Select) hasAggregate() bool {
+	for i := range selekt.Expressions {
+		if isAggregate(selekt.Expressions[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsEmpty returns true if statement is undefined.
 func (selekt Select) IsEmpty() bool {
 	return len(selekt.Expressions) == 0
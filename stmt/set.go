@@ -76,7 +76,7 @@ func (pairs *PairContainer) Add(column Column, expression Expression) {
 		pairs.Mode = PairAssociativeMode
 	}
 	if pairs.Mode != PairAssociativeMode {
-		panic("loukoum: you can only use pairs in key-value or column-list syntax")
+		panic(types.NewError(types.ErrInvalidPairs, "you can only use pairs in key-value or column-list syntax"))
 	}
 
 	_, ok := pairs.Map[column]
@@ -100,7 +100,7 @@ func (pairs *PairContainer) Set(column Column) {
 		pairs.Mode = PairArrayMode
 	}
 	if pairs.Mode != PairArrayMode {
-		panic("loukoum: you can only use pairs in key-value or column-list syntax")
+		panic(types.NewError(types.ErrInvalidPairs, "you can only use pairs in key-value or column-list syntax"))
 	}
 
 	pairs.Columns = append(pairs.Columns, column)
@@ -116,43 +116,65 @@ func (pairs *PairContainer) Set(column Column) {
 //
 func (pairs *PairContainer) Use(expression Expression) {
 	if pairs.Mode != PairArrayMode {
-		panic("loukoum: you have to define pairs columns first")
+		panic(types.NewError(types.ErrInvalidPairs, "you have to define pairs columns first"))
 	}
 
 	pairs.Expressions = append(pairs.Expressions, expression)
 }
 
 // Values returns columns and expressions of current instance.
+// Columns are sorted in a copy if they aren't already, since a PairContainer may be shared by builders used
+// concurrently.
 func (pairs PairContainer) Values() ([]Column, []Expression) {
 	if pairs.Mode != PairAssociativeMode {
 		return pairs.Columns, pairs.Expressions
 	}
 
-	sort.Slice(pairs.Columns, func(i, j int) bool {
-		return pairs.Columns[i].Name < pairs.Columns[j].Name ||
-			pairs.Columns[i].Alias < pairs.Columns[j].Alias
-	})
+	columns := pairs.Columns
+	if !isSorted(columns) {
+		columns = make([]Column, len(pairs.Columns))
+		copy(columns, pairs.Columns)
+		sort.Slice(columns, func(i, j int) bool {
+			return lessColumn(columns[i], columns[j])
+		})
+	}
 
-	columns := make([]Column, 0, len(pairs.Columns))
-	expressions := make([]Expression, 0, len(pairs.Columns))
+	expressions := make([]Expression, 0, len(columns))
 
-	for i := range pairs.Columns {
-		column := pairs.Columns[i]
-		expression, ok := pairs.Map[column]
+	for i := range columns {
+		expression, ok := pairs.Map[columns[i]]
 		if !ok {
-			panic("loukoum: invalid state for stmt.PairContainer")
+			panic(types.NewError(types.ErrInvalidPairs, "invalid state for stmt.PairContainer"))
 		}
-		columns = append(columns, column)
 		expressions = append(expressions, expression)
 	}
 
 	return columns, expressions
 }
 
+// isSorted returns true if given columns are already sorted, which avoids sorting them on every write.
+func isSorted(columns []Column) bool {
+	for i := 1; i < len(columns); i++ {
+		if lessColumn(columns[i], columns[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// lessColumn compares columns by name, then by alias.
+func lessColumn(a Column, b Column) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.Alias < b.Alias
+}
+
 // Write exposes statement as a SQL query.
 func (pairs PairContainer) Write(ctx types.Context) {
 	if pairs.IsEmpty() {
-		panic("loukoum: values for SET clause are required")
+		ctx.Fail(types.NewError(types.ErrInvalidPairs, "values for SET clause are required"))
+		return
 	}
 	if pairs.Mode == PairAssociativeMode {
 		pairs.WriteAssociative(ctx)
@@ -173,12 +195,14 @@ func (pairs PairContainer) WriteAssociative(ctx types.Context) {
 
 		columns[i].Write(ctx)
 		ctx.Write(" = ")
-		expressions[i].Write(ctx)
+		writeForColumn(ctx, columns[i].Name, expressions[i])
 	}
 }
 
 // WriteArray exposes statement as a SQL query using a column-list syntax.
 func (pairs PairContainer) WriteArray(ctx types.Context) {
+	requireFeature(ctx, types.FeatureSetColumnList)
+
 	ctx.Write("(")
 	for i := range pairs.Columns {
 		if i != 0 {
@@ -195,7 +219,11 @@ func (pairs PairContainer) WriteArray(ctx types.Context) {
 		if i != 0 {
 			ctx.Write(", ")
 		}
-		pairs.Expressions[i].Write(ctx)
+		if len(pairs.Expressions) == len(pairs.Columns) {
+			writeForColumn(ctx, pairs.Columns[i].Name, pairs.Expressions[i])
+		} else {
+			pairs.Expressions[i].Write(ctx)
+		}
 	}
 	ctx.Write(")")
 }
@@ -0,0 +1,38 @@
+package stmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3/stmt"
+)
+
+func TestPairContainer_Values(t *testing.T) {
+	is := require.New(t)
+
+	pairs := stmt.NewPairContainer()
+	pairs.Add(stmt.NewColumn("status"), stmt.NewValue("active"))
+	pairs.Add(stmt.NewColumnAlias("email", "z"), stmt.NewValue("tech@ulule.com"))
+	pairs.Add(stmt.NewColumnAlias("email", "a"), stmt.NewValue("contact@ulule.com"))
+	pairs.Add(stmt.NewColumn("created_at"), stmt.NewRaw("NOW()"))
+
+	// Columns are sorted by name, then by alias...
+	columns, expressions := pairs.Values()
+	is.Equal([]stmt.Column{
+		stmt.NewColumn("created_at"),
+		stmt.NewColumnAlias("email", "a"),
+		stmt.NewColumnAlias("email", "z"),
+		stmt.NewColumn("status"),
+	}, columns)
+	is.Equal([]stmt.Expression{
+		stmt.NewRaw("NOW()"),
+		stmt.NewValue("contact@ulule.com"),
+		stmt.NewValue("tech@ulule.com"),
+		stmt.NewValue("active"),
+	}, expressions)
+
+	// ...without reordering the columns of the container.
+	is.Equal(stmt.NewColumn("status"), pairs.Columns[0])
+	is.Equal(stmt.NewColumn("created_at"), pairs.Columns[3])
+}
@@ -14,6 +14,8 @@ type Update struct {
 	Set       Set
 	Where     Where
 	Returning Returning
+	// Errors are reported by the builder of the statement, if it's embedded in another one, such as a subquery.
+	Errors types.Errors
 }
 
 // NewUpdate returns a new Update instance.
@@ -26,8 +28,10 @@ func NewUpdate(table Table) Update {
 
 // Write exposes statement as a SQL query.
 func (update Update) Write(ctx types.Context) {
+	reportErrors(ctx, update.Errors)
 	if update.IsEmpty() {
-		panic("loukoum: an update statement must have a table and/or values")
+		ctx.Fail(types.NewError(types.ErrEmptyStatement, "an update statement must have a table and/or values"))
+		return
 	}
 
 	if !update.With.IsEmpty() {
@@ -38,6 +42,7 @@ func (update Update) Write(ctx types.Context) {
 	ctx.Write(token.Update.String())
 
 	if update.Only {
+		requireFeature(ctx, types.FeatureOnly)
 		ctx.Write(" ")
 		ctx.Write(token.Only.String())
 	}
@@ -49,6 +54,7 @@ func (update Update) Write(ctx types.Context) {
 	update.Set.Write(ctx)
 
 	if !update.From.IsEmpty() {
+		requireFeature(ctx, types.FeatureUpdateFrom)
 		ctx.Write(" ")
 		update.From.Write(ctx)
 	}
@@ -23,6 +23,8 @@ func (using Using) Write(ctx types.Context) {
 		return
 	}
 
+	requireFeature(ctx, types.FeatureUsing)
+
 	ctx.Write(token.Using.String())
 	ctx.Write(" ")
 
@@ -7,7 +7,10 @@ import (
 
 // Values is a VALUES clause.
 type Values struct {
+	// Values is the first row of the clause.
 	Values Expression
+	// Rows are the rows following the first one, when several rows are inserted.
+	Rows []Expression
 }
 
 // NewValues returns a new Values instance.
@@ -17,21 +20,92 @@ func NewValues(values Expression) Values {
 	}
 }
 
+// Append returns a new Values instance with given rows added.
+func (values Values) Append(rows ...Expression) Values {
+	if values.Values == nil && len(rows) > 0 {
+		values.Values, rows = rows[0], rows[1:]
+	}
+	list := make([]Expression, 0, len(values.Rows)+len(rows))
+	list = append(list, values.Rows...)
+	values.Rows = append(list, rows...)
+	return values
+}
+
+// All returns every row of the clause, starting with the first one.
+func (values Values) All() []Expression {
+	if values.Values == nil {
+		return nil
+	}
+	rows := make([]Expression, 0, len(values.Rows)+1)
+	rows = append(rows, values.Values)
+	return append(rows, values.Rows...)
+}
+
 // Write exposes statement as a SQL query.
 func (values Values) Write(ctx types.Context) {
+	values.write(ctx, nil)
+}
+
+// write exposes statement as a SQL query, naming the arguments of each row after given columns.
+func (values Values) write(ctx types.Context, columns []Column) {
 	if values.IsEmpty() {
 		return
 	}
 
+	rows := values.All()
 	ctx.Write(token.Values.String())
-	ctx.Write(" (")
-	values.Values.Write(ctx)
-	ctx.Write(")")
+	for i := range rows {
+		if i == 0 {
+			ctx.Write(" (")
+		} else {
+			ctx.Write(", (")
+		}
+		array, ok := rows[i].(Array)
+		switch {
+		case ok && len(columns) > 0 && len(array.Values) == len(columns):
+			for j := range array.Values {
+				if j > 0 {
+					ctx.Write(", ")
+				}
+				writeForColumn(ctx, columns[j].Name, array.Values[j])
+			}
+		case !ok && len(columns) == 1:
+			writeForColumn(ctx, columns[0].Name, rows[i])
+		default:
+			rows[i].Write(ctx)
+		}
+		ctx.Write(")")
+	}
 }
 
-// IsEmpty returns true if statement is undefined.
+// IsEmpty returns true if statement is undefined, or if one of its rows is.
 func (values Values) IsEmpty() bool {
-	return values.Values == nil || (values.Values != nil && values.Values.IsEmpty())
+	if values.Values == nil || values.Values.IsEmpty() {
+		return true
+	}
+	for i := range values.Rows {
+		if values.Rows[i] == nil || values.Rows[i].IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// arity returns the number of values of each row, or -1 if rows have a different number of values.
+func (values Values) arity() int {
+	arity := -1
+	rows := values.All()
+	for i := range rows {
+		count := 1
+		if array, ok := rows[i].(Array); ok {
+			count = len(array.Values)
+		}
+		if i > 0 && count != arity {
+			return -1
+		}
+		arity = count
+	}
+	return arity
 }
 
 // Ensure that Values is a Statement
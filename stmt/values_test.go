@@ -0,0 +1,62 @@
+package stmt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ulule/loukoum/v3/stmt"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+func TestValues(t *testing.T) {
+	is := require.New(t)
+
+	insert := stmt.NewInsert()
+	insert.Into = stmt.NewInto(stmt.NewTable("users"))
+	insert.Columns = []stmt.Column{stmt.NewColumn("email"), stmt.NewColumn("status")}
+
+	// A single row is given to NewValues...
+	{
+		query := insert
+		query.Values = stmt.NewValues(stmt.NewArrayExpression("tech@ulule.com", "active"))
+
+		ctx := types.NewStdContext(types.PostgreSQL)
+		query.Write(ctx)
+		is.NoError(ctx.Err())
+		is.Equal("INSERT INTO users (email, status) VALUES ($1, $2)", ctx.Query())
+		is.Equal([]interface{}{"tech@ulule.com", "active"}, ctx.Values())
+	}
+
+	// ...and the following ones are appended.
+	{
+		query := insert
+		query.Values = stmt.NewValues(stmt.NewArrayExpression("tech@ulule.com", "active")).
+			Append(stmt.NewArrayExpression("contact@ulule.com", "pending"))
+		is.Equal(stmt.NewArrayExpression("tech@ulule.com", "active"), query.Values.Values)
+		is.Len(query.Values.All(), 2)
+
+		ctx := types.NewStdContext(types.PostgreSQL)
+		query.Write(ctx)
+		is.NoError(ctx.Err())
+		is.Equal("INSERT INTO users (email, status) VALUES ($1, $2), ($3, $4)", ctx.Query())
+	}
+
+	// Every row must be defined.
+	{
+		query := insert
+		query.Values = stmt.NewValues(stmt.NewArrayExpression("tech@ulule.com", "active")).
+			Append(stmt.NewArrayExpression())
+		is.True(query.Values.IsEmpty())
+
+		ctx := types.NewStdContext(types.PostgreSQL)
+		query.Write(ctx)
+		is.True(errors.Is(ctx.Err(), types.ErrInvalidColumn))
+	}
+	{
+		is.True(stmt.Values{}.IsEmpty())
+		is.True(stmt.NewValues(nil).Append(nil).IsEmpty())
+		is.Nil(stmt.Values{}.All())
+	}
+}
@@ -12,6 +12,7 @@ type Where struct {
 
 // NewWhere returns a new Where instance.
 func NewWhere(expression Expression) Where {
+	requireCondition(expression)
 	return Where{
 		Condition: NewWrapper(expression),
 	}
@@ -20,7 +21,8 @@ func NewWhere(expression Expression) Where {
 // Write exposes statement as a SQL query.
 func (where Where) Write(ctx types.Context) {
 	if where.IsEmpty() {
-		panic("loukoum: a where clause expects at least one condition")
+		ctx.Fail(types.NewError(types.ErrInvalidCondition, "a where clause expects at least one condition"))
+		return
 	}
 
 	ctx.Write(token.Where.String())
@@ -36,8 +38,9 @@ func (where Where) IsEmpty() bool {
 // And appends given Expression using AND as logical operator.
 func (where Where) And(right Expression) Where {
 	if where.IsEmpty() {
-		panic("loukoum: two conditions are required for AND statement")
+		panic(types.NewError(types.ErrInvalidCondition, "two conditions are required for AND statement"))
 	}
+	requireCondition(right)
 
 	left := where.Condition
 	operator := NewAndOperator()
@@ -48,8 +51,9 @@ func (where Where) And(right Expression) Where {
 // Or appends given Expression using OR as logical operator.
 func (where Where) Or(right Expression) Where {
 	if where.IsEmpty() {
-		panic("loukoum: two conditions are required for OR statement")
+		panic(types.NewError(types.ErrInvalidCondition, "two conditions are required for OR statement"))
 	}
+	requireCondition(right)
 
 	left := where.Condition
 	operator := NewOrOperator()
@@ -57,5 +61,12 @@ func (where Where) Or(right Expression) Where {
 	return where
 }
 
+// requireCondition panics if given condition is undefined.
+func requireCondition(condition Expression) {
+	if condition == nil || condition.IsEmpty() {
+		panic(types.NewError(types.ErrInvalidCondition, "given condition is undefined"))
+	}
+}
+
 // Ensure that Where is a Statement
 var _ Statement = Where{}
@@ -0,0 +1,390 @@
+package stmt
+
+import (
+	"strconv"
+
+	"github.com/ulule/loukoum/v3/token"
+	"github.com/ulule/loukoum/v3/types"
+)
+
+// ----------------------------------------------------------------------------
+// Window
+// ----------------------------------------------------------------------------
+
+// Window is a window specification, used by an OVER clause or a WINDOW clause.
+type Window struct {
+	// Name is an existing window name, declared in a WINDOW clause, that this specification extends.
+	Name       string
+	Partitions []Expression
+	Orders     []Order
+	Frame      Frame
+}
+
+// NewWindow returns a new Window instance.
+func NewWindow() Window {
+	return Window{}
+}
+
+// Base defines the name of an existing window, declared in a WINDOW clause, that this specification extends.
+func (window Window) Base(name string) Window {
+	window.Name = name
+	return window
+}
+
+// PartitionBy adds PARTITION BY expressions, which are either column names, Column or Expression instances.
+func (window Window) PartitionBy(values ...interface{}) Window {
+	partitions := make([]Expression, 0, len(window.Partitions)+len(values))
+	partitions = append(partitions, window.Partitions...)
+	for i := range values {
+		partitions = append(partitions, toPartition(values[i]))
+	}
+	window.Partitions = partitions
+	return window
+}
+
+// OrderBy adds ORDER BY expressions.
+func (window Window) OrderBy(orders ...Order) Window {
+	list := make([]Order, 0, len(window.Orders)+len(orders))
+	list = append(list, window.Orders...)
+	window.Orders = append(list, orders...)
+	return window
+}
+
+// Rows defines a frame using ROWS mode. If end is omitted, the frame ends with the current row.
+func (window Window) Rows(start FrameBound, end ...FrameBound) Window {
+	window.Frame = NewFrame(types.Rows, start, toFrameEnd(end))
+	return window
+}
+
+// Range defines a frame using RANGE mode. If end is omitted, the frame ends with the current row.
+func (window Window) Range(start FrameBound, end ...FrameBound) Window {
+	window.Frame = NewFrame(types.Range, start, toFrameEnd(end))
+	return window
+}
+
+// Groups defines a frame using GROUPS mode. If end is omitted, the frame ends with the current row.
+func (window Window) Groups(start FrameBound, end ...FrameBound) Window {
+	window.Frame = NewFrame(types.Groups, start, toFrameEnd(end))
+	return window
+}
+
+// Write exposes statement as a SQL query.
+func (window Window) Write(ctx types.Context) {
+	requireFeature(ctx, types.FeatureWindow)
+
+	ctx.Write("(")
+	separator := ""
+
+	if window.Name != "" {
+		writeAlias(ctx, window.Name)
+		separator = " "
+	}
+
+	if len(window.Partitions) > 0 {
+		ctx.Write(separator)
+		ctx.Write(token.Partition.String())
+		ctx.Write(" ")
+		ctx.Write(token.By.String())
+		ctx.Write(" ")
+		for i := range window.Partitions {
+			if i > 0 {
+				ctx.Write(", ")
+			}
+			window.Partitions[i].Write(ctx)
+		}
+		separator = " "
+	}
+
+	if len(window.Orders) > 0 {
+		ctx.Write(separator)
+		NewOrderBy(window.Orders).Write(ctx)
+		separator = " "
+	}
+
+	if !window.Frame.IsEmpty() {
+		ctx.Write(separator)
+		window.Frame.Write(ctx)
+	}
+
+	ctx.Write(")")
+}
+
+// IsEmpty returns true if statement is undefined.
+func (window Window) IsEmpty() bool {
+	return window.Name == "" && len(window.Partitions) == 0 && len(window.Orders) == 0 && window.Frame.IsEmpty()
+}
+
+// isReference returns true if window only references an existing window.
+func (window Window) isReference() bool {
+	return window.Name != "" && len(window.Partitions) == 0 && len(window.Orders) == 0 && window.Frame.IsEmpty()
+}
+
+// toFrameEnd returns the end bound of a frame, if it's defined.
+func toFrameEnd(end []FrameBound) FrameBound {
+	if len(end) > 0 {
+		return end[0]
+	}
+	return FrameBound{}
+}
+
+// toPartition returns a PARTITION BY expression from given value.
+func toPartition(arg interface{}) Expression {
+	switch value := arg.(type) {
+	case string:
+		return NewIdentifier(value)
+	case Column:
+		return NewIdentifier(value.Name)
+	case Expression:
+		return value
+	default:
+		panic(types.NewErrorf(types.ErrInvalidExpression, "cannot use {%+v}[%T] as partition", arg, arg))
+	}
+}
+
+// writeOver writes an OVER clause using given window, if it's defined.
+func writeOver(ctx types.Context, window *Window) {
+	if window == nil {
+		return
+	}
+
+	windowCtx, ok := ctx.(types.WindowContext)
+	if ok && window.Name != "" && !windowCtx.HasWindow(window.Name) {
+		ctx.Fail(types.NewErrorf(types.ErrInvalidClause, "window %s is not defined", window.Name))
+		return
+	}
+
+	ctx.Write(" ")
+	ctx.Write(token.Over.String())
+	ctx.Write(" ")
+
+	if window.isReference() {
+		requireFeature(ctx, types.FeatureWindow)
+		writeAlias(ctx, window.Name)
+		return
+	}
+
+	window.Write(ctx)
+}
+
+// Ensure that Window is a Statement
+var _ Statement = Window{}
+
+// ----------------------------------------------------------------------------
+// Frame
+// ----------------------------------------------------------------------------
+
+// Frame is a frame clause of a window specification.
+type Frame struct {
+	Mode  types.FrameMode
+	Start FrameBound
+	End   FrameBound
+}
+
+// NewFrame returns a new Frame instance.
+func NewFrame(mode types.FrameMode, start FrameBound, end FrameBound) Frame {
+	return Frame{
+		Mode:  mode,
+		Start: start,
+		End:   end,
+	}
+}
+
+// Write exposes statement as a SQL query.
+func (frame Frame) Write(ctx types.Context) {
+	if frame.Start.IsEmpty() {
+		ctx.Fail(types.NewError(types.ErrInvalidClause, "frame clause requires a start bound"))
+		return
+	}
+	if !frame.isOrdered() {
+		ctx.Fail(types.NewError(types.ErrInvalidClause, "frame clause bounds are inverted"))
+		return
+	}
+	if frame.Mode == types.Groups {
+		requireFeature(ctx, types.FeatureFrameGroups)
+	}
+
+	ctx.Write(frame.Mode.String())
+	ctx.Write(" ")
+
+	if frame.End.IsEmpty() {
+		frame.Start.Write(ctx)
+		return
+	}
+
+	ctx.Write(token.Between.String())
+	ctx.Write(" ")
+	frame.Start.Write(ctx)
+	ctx.Write(" ")
+	ctx.Write(token.And.String())
+	ctx.Write(" ")
+	frame.End.Write(ctx)
+}
+
+// IsEmpty returns true if statement is undefined.
+func (frame Frame) IsEmpty() bool {
+	return frame.Mode == ""
+}
+
+// isOrdered returns true if the start bound of the frame doesn't come after its end bound, which is the current
+// row if it's omitted, such as "CURRENT ROW AND 1 PRECEDING".
+func (frame Frame) isOrdered() bool {
+	end := frame.End
+	if end.IsEmpty() {
+		end = NewFrameBound(types.CurrentRow, 0)
+	}
+	if frame.Start.Type == types.UnboundedFollowing || end.Type == types.UnboundedPreceding {
+		return false
+	}
+	return frame.Start.position() <= end.position()
+}
+
+// Ensure that Frame is a Statement
+var _ Statement = Frame{}
+
+// ----------------------------------------------------------------------------
+// FrameBound
+// ----------------------------------------------------------------------------
+
+// FrameBound is the start or the end of a window frame.
+type FrameBound struct {
+	Type   types.FrameBoundType
+	Offset int64
+}
+
+// NewFrameBound returns a new FrameBound instance.
+func NewFrameBound(kind types.FrameBoundType, offset int64) FrameBound {
+	return FrameBound{
+		Type:   kind,
+		Offset: offset,
+	}
+}
+
+// Write exposes statement as a SQL query.
+func (bound FrameBound) Write(ctx types.Context) {
+	if bound.Type == types.Preceding || bound.Type == types.Following {
+		if bound.Offset < 0 {
+			ctx.Fail(types.NewError(types.ErrInvalidClause, "frame offset must be a non-negative integer"))
+			return
+		}
+		ctx.Write(strconv.FormatInt(bound.Offset, 10))
+		ctx.Write(" ")
+	}
+	ctx.Write(bound.Type.String())
+}
+
+// IsEmpty returns true if statement is undefined.
+func (bound FrameBound) IsEmpty() bool {
+	return bound.Type == ""
+}
+
+// position returns the position of the bound relative to the current row, ignoring its offset.
+func (bound FrameBound) position() int {
+	switch bound.Type {
+	case types.UnboundedPreceding:
+		return -2
+	case types.Preceding:
+		return -1
+	case types.Following:
+		return 1
+	case types.UnboundedFollowing:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Ensure that FrameBound is a Statement
+var _ Statement = FrameBound{}
+
+// ----------------------------------------------------------------------------
+// WindowClause
+// ----------------------------------------------------------------------------
+
+// WindowClause is a WINDOW clause.
+type WindowClause struct {
+	Windows []NamedWindow
+}
+
+// NewWindowClause returns a new WindowClause instance.
+func NewWindowClause(windows []NamedWindow) WindowClause {
+	return WindowClause{
+		Windows: windows,
+	}
+}
+
+// Write exposes statement as a SQL query.
+func (clause WindowClause) Write(ctx types.Context) {
+	if clause.IsEmpty() {
+		return
+	}
+
+	ctx.Write(token.Window.String())
+	ctx.Write(" ")
+	for i := range clause.Windows {
+		if i > 0 {
+			ctx.Write(", ")
+		}
+		clause.Windows[i].Write(ctx)
+	}
+}
+
+// IsEmpty returns true if statement is undefined.
+func (clause WindowClause) IsEmpty() bool {
+	return len(clause.Windows) == 0
+}
+
+// Names returns the names of the declared windows, or nil if there is none.
+func (clause WindowClause) Names() []string {
+	if clause.IsEmpty() {
+		return nil
+	}
+	names := make([]string, len(clause.Windows))
+	for i := range clause.Windows {
+		names[i] = clause.Windows[i].Name
+	}
+	return names
+}
+
+// Has returns true if a window with given name is declared.
+func (clause WindowClause) Has(name string) bool {
+	for i := range clause.Windows {
+		if clause.Windows[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure that WindowClause is a Statement
+var _ Statement = WindowClause{}
+
+// NamedWindow is a window declared in a WINDOW clause.
+type NamedWindow struct {
+	Name   string
+	Window Window
+}
+
+// NewNamedWindow returns a new NamedWindow instance.
+func NewNamedWindow(name string, window Window) NamedWindow {
+	return NamedWindow{
+		Name:   name,
+		Window: window,
+	}
+}
+
+// Write exposes statement as a SQL query.
+func (window NamedWindow) Write(ctx types.Context) {
+	writeAlias(ctx, window.Name)
+	ctx.Write(" ")
+	ctx.Write(token.As.String())
+	ctx.Write(" ")
+	window.Window.Write(ctx)
+}
+
+// IsEmpty returns true if statement is undefined.
+func (window NamedWindow) IsEmpty() bool {
+	return window.Name == ""
+}
+
+// Ensure that NamedWindow is a Statement
+var _ Statement = NamedWindow{}
@@ -24,6 +24,10 @@ func (with With) Write(ctx types.Context) {
 	}
 	ctx.Write(token.With.String())
 	ctx.Write(" ")
+	if with.IsRecursive() {
+		ctx.Write(token.Recursive.String())
+		ctx.Write(" ")
+	}
 	for i := range with.Queries {
 		if i != 0 {
 			ctx.Write(", ")
@@ -37,21 +41,79 @@ func (with With) IsEmpty() bool {
 	return len(with.Queries) == 0
 }
 
+// IsRecursive returns true if a query of the clause is recursive.
+func (with With) IsRecursive() bool {
+	for i := range with.Queries {
+		if with.Queries[i].IsRecursive {
+			return true
+		}
+	}
+	return false
+}
+
 // WithQuery is a statement in a With clause.
 type WithQuery struct {
-	Name     string
-	Subquery Expression
+	Name            string
+	ColumnNames     []string
+	Materialization types.Materialization
+	IsRecursive     bool
+	Subquery        Statement
+}
+
+// Columns defines the column names of the query.
+func (with WithQuery) Columns(names ...string) WithQuery {
+	with.ColumnNames = names
+	return with
+}
+
+// Recursive marks the query as recursive, so it can refer to its own output.
+// Its subquery is usually a compound statement, such as "anchor UNION ALL recursive member".
+func (with WithQuery) Recursive() WithQuery {
+	with.IsRecursive = true
+	return with
+}
+
+// Materialized forces the query to be computed once.
+func (with WithQuery) Materialized() WithQuery {
+	with.Materialization = types.Materialized
+	return with
+}
+
+// NotMaterialized allows the query to be folded into the parent query.
+func (with WithQuery) NotMaterialized() WithQuery {
+	with.Materialization = types.NotMaterialized
+	return with
 }
 
 // Write exposes statement as a SQL query.
 func (with WithQuery) Write(ctx types.Context) {
 	if with.IsEmpty() {
+		ctx.Fail(types.NewError(types.ErrEmptyStatement, "with queries must have a name and a subquery"))
 		return
 	}
-	ctx.Write(with.Name)
+	writeAlias(ctx, with.Name)
+	if len(with.ColumnNames) > 0 {
+		ctx.Write("(")
+		for i := range with.ColumnNames {
+			if i != 0 {
+				ctx.Write(", ")
+			}
+			writeAlias(ctx, with.ColumnNames[i])
+		}
+		ctx.Write(")")
+	}
 	ctx.Write(" ")
 	ctx.Write(token.As.String())
-	ctx.Write(" (")
+	ctx.Write(" ")
+	if isDataModifying(with.Subquery) {
+		requireFeature(ctx, types.FeatureDataModifyingWith)
+	}
+	if with.Materialization != "" {
+		requireFeature(ctx, types.FeatureMaterialized)
+		ctx.Write(with.Materialization.String())
+		ctx.Write(" ")
+	}
+	ctx.Write("(")
 	with.Subquery.Write(ctx)
 	ctx.Write(")")
 }
@@ -62,9 +124,31 @@ func (with WithQuery) IsEmpty() bool {
 }
 
 // NewWithQuery returns a new WithQuery instance.
+// Its subquery is either a query expression, or an INSERT, UPDATE or DELETE statement.
 func NewWithQuery(name string, value interface{}) WithQuery {
 	return WithQuery{
 		Name:     name,
-		Subquery: NewExpression(value),
+		Subquery: toWithSubquery(value),
+	}
+}
+
+// toWithSubquery returns given value as the subquery of a WithQuery.
+func toWithSubquery(arg interface{}) Statement {
+	if encoder, ok := arg.(StatementEncoder); ok {
+		arg = encoder.Statement()
+	}
+	if isDataModifying(arg) {
+		return arg.(Statement)
+	}
+	return NewExpression(arg)
+}
+
+// isDataModifying returns true if given value is an INSERT, UPDATE or DELETE statement.
+func isDataModifying(arg interface{}) bool {
+	switch arg.(type) {
+	case Insert, Update, Delete:
+		return true
+	default:
+		return false
 	}
 }
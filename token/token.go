@@ -74,6 +74,21 @@ const (
 	Max       = Type("MAX")
 	Min       = Type("MIN")
 	Sum       = Type("SUM")
+	Duplicate = Type("DUPLICATE")
+	Key       = Type("KEY")
+	Excluded  = Type("EXCLUDED")
+	Case      = Type("CASE")
+	When      = Type("WHEN")
+	Then      = Type("THEN")
+	Else      = Type("ELSE")
+	End       = Type("END")
+	Over      = Type("OVER")
+	Partition = Type("PARTITION")
+	Window    = Type("WINDOW")
+	Between   = Type("BETWEEN")
+	Of        = Type("OF")
+	Recursive = Type("RECURSIVE")
+	Default   = Type("DEFAULT")
 )
 
 // A Token is defined by its type and a value.
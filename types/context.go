@@ -1,8 +1,9 @@
 package types
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/ulule/loukoum/v3/format"
 )
@@ -11,46 +12,338 @@ import (
 type Context interface {
 	Write(query string)
 	Bind(value interface{})
+	Dialect() Dialect
+	Fail(err error)
 }
 
+// PlaceholderStyle defines how positional placeholders are written, regardless of the dialect.
+// A zero PlaceholderStyle uses the placeholders of the dialect.
+type PlaceholderStyle struct {
+	// Prefix is written before the index of the argument, or alone if placeholders aren't numbered.
+	Prefix string
+	// Numbered appends the index of the argument, starting at 1, to the prefix.
+	Numbered bool
+	// Escape doubles literal "?" characters of the query, such as in Raw expressions. Only use it with a
+	// rebinder that turns "??" back into "?", such as the Dollar, Colon and AtP formats of squirrel:
+	// sqlx's Rebind and database/sql drivers don't, and would send "??" to the database.
+	Escape bool
+}
+
+// Placeholder styles.
+var (
+	// DollarPlaceholder is used for "$1" placeholders, as expected by PostgreSQL.
+	DollarPlaceholder = PlaceholderStyle{Prefix: "$", Numbered: true}
+	// QuestionPlaceholder is used for "?" placeholders, as expected by MySQL.
+	QuestionPlaceholder = PlaceholderStyle{Prefix: "?"}
+	// RebindPlaceholder is used for "?" placeholders, whose literal "?" characters are escaped as "??",
+	// for a rebinder that unescapes them, such as the numbered formats of squirrel.
+	RebindPlaceholder = PlaceholderStyle{Prefix: "?", Escape: true}
+	// AtPlaceholder is used for "@p1" placeholders, as expected by SQL Server.
+	AtPlaceholder = PlaceholderStyle{Prefix: "@p", Numbered: true}
+	// ColonPlaceholder is used for ":1" placeholders, as expected by Oracle.
+	ColonPlaceholder = PlaceholderStyle{Prefix: ":", Numbered: true}
+)
+
+// IsZero returns true if style is undefined.
+func (style PlaceholderStyle) IsZero() bool {
+	return style == PlaceholderStyle{}
+}
+
+// AppendPlaceholder appends the positional placeholder of the argument at given index to dst.
+func (style PlaceholderStyle) AppendPlaceholder(dst []byte, index int) []byte {
+	dst = append(dst, style.Prefix...)
+	if !style.Numbered {
+		return dst
+	}
+	return strconv.AppendInt(dst, int64(index), 10)
+}
+
+// NamingStyle defines how the arguments of a named query are named.
+type NamingStyle int
+
+// Naming styles.
+const (
+	// IndexNaming names arguments after their position, such as "arg_1".
+	IndexNaming NamingStyle = iota
+	// ColumnNaming names arguments after the column they're compared with or assigned to, such as "email",
+	// suffixed by a counter on collision, such as "email_2". Other arguments are named after their position.
+	ColumnNaming
+)
+
+// A ColumnContext is a Context that can name arguments after the column they're compared with or assigned to.
+type ColumnContext interface {
+	Context
+	// SetColumn defines the column of the next arguments, or none if it's empty, and returns the previous one.
+	SetColumn(column string) string
+}
+
+// A WindowContext is a Context that knows the windows declared by the WINDOW clause of the query being written.
+type WindowContext interface {
+	Context
+	// SetWindows defines the names of the declared windows, and returns the previous ones.
+	SetWindows(names []string) []string
+	// HasWindow returns true if a window with given name is declared.
+	HasWindow(name string) bool
+}
+
+// maxPooledBuffer is the maximum capacity of a buffer kept by a pooled context,
+// so that a huge query doesn't retain its memory.
+const maxPooledBuffer = 64 * 1024
+
+var (
+	rawContextPool   = sync.Pool{New: func() interface{} { return &RawContext{} }}
+	namedContextPool = sync.Pool{New: func() interface{} { return &NamedContext{} }}
+	stdContextPool   = sync.Pool{New: func() interface{} { return &StdContext{} }}
+)
+
 // RawContext embeds values directly in the query.
 type RawContext struct {
-	buffer strings.Builder
+	buffer  []byte
+	dialect Dialect
+	errs    Errors
+	windows []string
+}
+
+// NewRawContext returns a new RawContext instance using given dialect.
+func NewRawContext(dialect Dialect) *RawContext {
+	return &RawContext{
+		dialect: dialect,
+	}
+}
+
+// AcquireRawContext returns a RawContext instance from a pool, using given dialect.
+// Pooled contexts keep the capacity of their buffer, so that queries of similar size are written without
+// growing it. The context must be released once its query is retrieved.
+func AcquireRawContext(dialect Dialect) *RawContext {
+	ctx := rawContextPool.Get().(*RawContext)
+	ctx.dialect = dialect
+	return ctx
+}
+
+// Release puts back a context obtained from AcquireRawContext in the pool.
+// The context must not be used afterward.
+func (ctx *RawContext) Release() {
+	ctx.reset()
+	rawContextPool.Put(ctx)
+}
+
+func (ctx *RawContext) reset() {
+	ctx.buffer = ctx.buffer[:0]
+	if cap(ctx.buffer) > maxPooledBuffer {
+		ctx.buffer = nil
+	}
+	ctx.dialect = nil
+	ctx.errs = nil
+	ctx.windows = nil
 }
 
 // Write appends given subquery in context's buffer.
 func (ctx *RawContext) Write(query string) {
-	_, err := ctx.buffer.WriteString(query)
-	if err != nil {
-		panic("loukoum: cannot write on buffer")
-	}
+	ctx.buffer = append(ctx.buffer, query...)
 }
 
 // Bind adds given value in context's values.
 func (ctx *RawContext) Bind(value interface{}) {
+	param, ok := value.(Param)
+	if ok {
+		ctx.buffer = append(ctx.buffer, ':')
+		ctx.Write(param.Name)
+		return
+	}
 	ctx.Write(format.Value(value))
 }
 
+// SetWindows defines the names of the windows declared by the query being written, and returns the previous ones.
+func (ctx *RawContext) SetWindows(names []string) []string {
+	previous := ctx.windows
+	ctx.windows = names
+	return previous
+}
+
+// HasWindow returns true if a window with given name is declared by the query being written.
+func (ctx *RawContext) HasWindow(name string) bool {
+	for i := range ctx.windows {
+		if ctx.windows[i] == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Query returns the underlaying query.
 func (ctx *RawContext) Query() string {
-	return ctx.buffer.String()
+	return string(ctx.buffer)
+}
+
+// Fail adds given error in context's errors.
+func (ctx *RawContext) Fail(err error) {
+	ctx.errs = append(ctx.errs, err)
+}
+
+// Err returns errors found while writing the query, or nil if the query is valid.
+func (ctx *RawContext) Err() error {
+	return ctx.errs.Err()
+}
+
+// Dialect returns the context's dialect.
+// If no dialect was defined, PostgreSQL is used.
+func (ctx *RawContext) Dialect() Dialect {
+	if ctx.dialect == nil {
+		return PostgreSQL
+	}
+	return ctx.dialect
 }
 
 // NamedContext uses named query placeholders.
 type NamedContext struct {
 	RawContext
-	values map[string]interface{}
+	values   map[string]interface{}
+	index    int
+	naming   NamingStyle
+	column   string
+	params   map[string]bool
+	conflict bool
+}
+
+// NewNamedContext returns a new NamedContext instance using given dialect.
+func NewNamedContext(dialect Dialect) *NamedContext {
+	return &NamedContext{
+		RawContext: RawContext{
+			dialect: dialect,
+		},
+	}
+}
+
+// AcquireNamedContext returns a NamedContext instance from a pool, using given dialect.
+// The context must be released once its query and values are retrieved.
+func AcquireNamedContext(dialect Dialect) *NamedContext {
+	ctx := namedContextPool.Get().(*NamedContext)
+	ctx.dialect = dialect
+	return ctx
+}
+
+// Release puts back a context obtained from AcquireNamedContext in the pool.
+// The context must not be used afterward, but its values can.
+func (ctx *NamedContext) Release() {
+	ctx.reset()
+	ctx.values = nil
+	ctx.index = 0
+	ctx.naming = IndexNaming
+	ctx.column = ""
+	ctx.params = nil
+	ctx.conflict = false
+	namedContextPool.Put(ctx)
+}
+
+// SetNamingStyle defines how arguments are named. It must be called before writing the query.
+func (ctx *NamedContext) SetNamingStyle(style NamingStyle) {
+	ctx.naming = style
+}
+
+// SetColumn defines the column of the next arguments, or none if it's empty, and returns the previous one.
+func (ctx *NamedContext) SetColumn(column string) string {
+	previous := ctx.column
+	ctx.column = column
+	return previous
 }
 
 // Bind adds given value in context's values.
+// A parameter is bound to its own name, and isn't duplicated if it's used many times. Generated names avoid
+// the names of parameters bound before them: if a parameter uses the name of an argument generated before it,
+// the context reports a conflict, and the statement must be written again after a call to Rewind().
 func (ctx *NamedContext) Bind(value interface{}) {
 	if ctx.values == nil {
 		ctx.values = make(map[string]interface{})
 	}
-	idx := len(ctx.values) + 1
-	name := fmt.Sprintf("arg_%d", idx)
-	ctx.values[name] = value
-	ctx.Write(":" + name)
+
+	param, isParam := value.(Param)
+	if isParam {
+		ctx.bindParam(param)
+	} else {
+		param = Param{Name: ctx.argName()}
+		ctx.values[param.Name] = value
+	}
+
+	ctx.buffer = append(ctx.buffer, ':')
+	ctx.Write(param.Name)
+}
+
+func (ctx *NamedContext) bindParam(param Param) {
+	if ctx.params == nil {
+		ctx.params = make(map[string]bool)
+	}
+
+	_, ok := ctx.values[param.Name]
+	if ok && !ctx.params[param.Name] {
+		ctx.conflict = true
+	}
+
+	ctx.params[param.Name] = true
+	ctx.values[param.Name] = param
+}
+
+// HasConflict returns true if a parameter uses the name of an argument generated before it.
+func (ctx *NamedContext) HasConflict() bool {
+	return ctx.conflict
+}
+
+// Rewind resets the query, values and errors of the context, so that the statement is written again.
+// Names of the parameters bound so far are kept, and avoided by generated names.
+func (ctx *NamedContext) Rewind() {
+	ctx.buffer = ctx.buffer[:0]
+	ctx.errs = nil
+	ctx.values = nil
+	ctx.index = 0
+	ctx.column = ""
+	ctx.conflict = false
+}
+
+// argName returns the name of the next argument, which is neither used by another argument nor by a parameter.
+func (ctx *NamedContext) argName() string {
+	if ctx.naming != ColumnNaming || ctx.column == "" {
+		name := ""
+		for ok := true; ok; ok = ctx.isUsed(name) {
+			ctx.index++
+			name = "arg_" + strconv.Itoa(ctx.index)
+		}
+		return name
+	}
+
+	ctx.index++
+	prefix := toArgName(ctx.column)
+	name := prefix
+	for i := 2; ctx.isUsed(name); i++ {
+		name = prefix + "_" + strconv.Itoa(i)
+	}
+
+	return name
+}
+
+func (ctx *NamedContext) isUsed(name string) bool {
+	_, ok := ctx.values[name]
+	return ok || ctx.params[name]
+}
+
+// toArgName returns an argument name for given column, such as "email" for "users.email".
+func toArgName(column string) string {
+	column = column[strings.LastIndex(column, ".")+1:]
+
+	name := make([]byte, 0, len(column))
+	for i := 0; i < len(column); i++ {
+		char := column[i]
+		switch {
+		case char == '_', 'a' <= char && char <= 'z', 'A' <= char && char <= 'Z', '0' <= char && char <= '9':
+			name = append(name, char)
+		case char == '"', char == '`':
+		default:
+			name = append(name, '_')
+		}
+	}
+
+	if len(name) == 0 || ('0' <= name[0] && name[0] <= '9') {
+		return "arg_" + string(name)
+	}
+	return string(name)
 }
 
 // Values returns the named argument values.
@@ -62,16 +355,116 @@ func (ctx *NamedContext) Values() map[string]interface{} {
 type StdContext struct {
 	RawContext
 	values []interface{}
+	params map[string]int
+	style  PlaceholderStyle
+}
+
+// NewStdContext returns a new StdContext instance using given dialect.
+func NewStdContext(dialect Dialect) *StdContext {
+	return &StdContext{
+		RawContext: RawContext{
+			dialect: dialect,
+		},
+	}
+}
+
+// AcquireStdContext returns a StdContext instance from a pool, using given dialect.
+// The context must be released once its query and values are retrieved.
+func AcquireStdContext(dialect Dialect) *StdContext {
+	ctx := stdContextPool.Get().(*StdContext)
+	ctx.dialect = dialect
+	return ctx
+}
+
+// Release puts back a context obtained from AcquireStdContext in the pool.
+// The context must not be used afterward, but its values can.
+func (ctx *StdContext) Release() {
+	ctx.reset()
+	for i := range ctx.values {
+		ctx.values[i] = nil
+	}
+	ctx.values = ctx.values[:0]
+	ctx.params = nil
+	ctx.style = PlaceholderStyle{}
+	stdContextPool.Put(ctx)
+}
+
+// SetPlaceholderStyle defines the style of placeholders, instead of the placeholders of the dialect.
+// It must be called before writing the query.
+func (ctx *StdContext) SetPlaceholderStyle(style PlaceholderStyle) {
+	ctx.style = style
+}
+
+// Write appends given subquery in context's buffer.
+// Literal "?" characters are doubled if the placeholder style requires it.
+func (ctx *StdContext) Write(query string) {
+	if !ctx.style.Escape {
+		ctx.buffer = append(ctx.buffer, query...)
+		return
+	}
+	for i := 0; i < len(query); i++ {
+		if query[i] == '?' {
+			ctx.buffer = append(ctx.buffer, '?')
+		}
+		ctx.buffer = append(ctx.buffer, query[i])
+	}
 }
 
 // Bind adds given value in context's values.
+// A parameter used many times reuses its placeholder if the dialect supports numbered placeholders.
 func (ctx *StdContext) Bind(value interface{}) {
-	idx := len(ctx.values) + 1
+	param, ok := value.(Param)
+	if ok && ctx.isNumbered() {
+		idx, ok := ctx.params[param.Name]
+		if ok {
+			ctx.writePlaceholder(idx)
+			return
+		}
+		if ctx.params == nil {
+			ctx.params = make(map[string]int)
+		}
+		ctx.params[param.Name] = len(ctx.values) + 1
+	}
+
 	ctx.values = append(ctx.values, value)
-	ctx.Write(fmt.Sprintf("$%d", idx))
+	ctx.writePlaceholder(len(ctx.values))
+}
+
+// isNumbered returns true if placeholders can be reused, such as "$1".
+func (ctx *StdContext) isNumbered() bool {
+	if !ctx.style.IsZero() {
+		return ctx.style.Numbered
+	}
+	return ctx.Dialect().Supports(FeatureNumberedPlaceholders)
 }
 
-// Values returns the positional argument values.
+func (ctx *StdContext) writePlaceholder(idx int) {
+	if !ctx.style.IsZero() {
+		ctx.buffer = ctx.style.AppendPlaceholder(ctx.buffer, idx)
+		return
+	}
+
+	dialect := ctx.Dialect()
+	appender, ok := dialect.(placeholderAppender)
+	if ok {
+		ctx.buffer = appender.AppendPlaceholder(ctx.buffer, idx)
+		return
+	}
+	ctx.Write(dialect.Placeholder(idx))
+}
+
+// Values returns a copy of the positional argument values, or nil if there is none.
 func (ctx *StdContext) Values() []interface{} {
-	return ctx.values
+	if len(ctx.values) == 0 {
+		return nil
+	}
+	values := make([]interface{}, len(ctx.values))
+	copy(values, ctx.values)
+	return values
 }
+
+// Ensure that NamedContext is a ColumnContext
+var _ ColumnContext = &NamedContext{}
+
+// Ensure that RawContext is a WindowContext
+var _ WindowContext = &RawContext{}
@@ -0,0 +1,256 @@
+package types
+
+import (
+	"strconv"
+)
+
+// Feature represents a SQL capability that is not shared by every dialect.
+type Feature string
+
+func (e Feature) String() string {
+	return string(e)
+}
+
+// Dialect features.
+const (
+	// FeatureReturning is used for "RETURNING" clause.
+	FeatureReturning = Feature("RETURNING clause")
+	// FeatureOnly is used for "ONLY" clause.
+	FeatureOnly = Feature("ONLY clause")
+	// FeatureILike is used for "ILIKE" and "NOT ILIKE" operators.
+	FeatureILike = Feature("ILIKE operator")
+	// FeatureUsing is used for "USING" clause in delete statement.
+	FeatureUsing = Feature("USING clause")
+	// FeatureUpdateFrom is used for "FROM" clause in update statement.
+	FeatureUpdateFrom = Feature("FROM clause in UPDATE statement")
+	// FeatureSetColumnList is used for column-list syntax of "SET" clause.
+	FeatureSetColumnList = Feature("column-list syntax in SET clause")
+	// FeatureOnConflict is used for "ON CONFLICT" clause.
+	FeatureOnConflict = Feature("ON CONFLICT clause")
+	// FeatureOnDuplicateKey is used for "ON DUPLICATE KEY UPDATE" clause.
+	FeatureOnDuplicateKey = Feature("ON DUPLICATE KEY UPDATE clause")
+	// FeatureConcatOperator is used for "||" string concatenation operator.
+	FeatureConcatOperator = Feature("|| concatenation operator")
+	// FeatureWindow is used for window functions and "WINDOW" clause.
+	FeatureWindow = Feature("window functions")
+	// FeatureFrameGroups is used for "GROUPS" mode of window frames.
+	FeatureFrameGroups = Feature("GROUPS frame mode")
+	// FeatureCompoundParenthesis is used for parenthesized queries in UNION, INTERSECT and EXCEPT statements.
+	FeatureCompoundParenthesis = Feature("parenthesized query in compound statement")
+	// FeatureDistinctOn is used for "DISTINCT ON" clause.
+	FeatureDistinctOn = Feature("DISTINCT ON clause")
+	// FeatureMaterialized is used for "MATERIALIZED" and "NOT MATERIALIZED" hints of common table expressions.
+	FeatureMaterialized = Feature("MATERIALIZED hint in WITH clause")
+	// FeatureDataModifyingWith is used for INSERT, UPDATE and DELETE statements in "WITH" clause.
+	FeatureDataModifyingWith = Feature("data-modifying statement in WITH clause")
+	// FeatureInsertWith is used for "WITH" clause in insert statement.
+	FeatureInsertWith = Feature("WITH clause in INSERT statement")
+	// FeatureLocking is used for "FOR UPDATE" and "FOR SHARE" row-level locking clauses.
+	FeatureLocking = Feature("row-level locking clause")
+	// FeatureKeyLocking is used for "FOR NO KEY UPDATE" and "FOR KEY SHARE" row-level locking clauses.
+	FeatureKeyLocking = Feature("key row-level locking clause")
+	// FeatureNumberedPlaceholders is used for placeholders that can be reused, such as "$1", by named parameters.
+	FeatureNumberedPlaceholders = Feature("numbered placeholders")
+)
+
+// A Dialect defines how a statement is rendered for a given database engine.
+type Dialect interface {
+	// Name returns the dialect name.
+	Name() string
+	// Placeholder returns the positional placeholder of the argument at given index (starting at 1).
+	Placeholder(index int) string
+	// QuoteIdentifier returns given identifier, such as "schema.table.column", quoted for this dialect.
+	QuoteIdentifier(identifier string) (string, error)
+	// QuoteAlias returns given alias quoted for this dialect.
+	// A dotted alias, such as "author.id", is quoted as a single name.
+	QuoteAlias(alias string) (string, error)
+	// Supports returns true if dialect supports given feature.
+	Supports(feature Feature) bool
+}
+
+// placeholderAppender is implemented by dialects that append placeholders to a buffer without allocation.
+type placeholderAppender interface {
+	// AppendPlaceholder appends the positional placeholder of the argument at given index to dst.
+	AppendPlaceholder(dst []byte, index int) []byte
+}
+
+// Dialects.
+var (
+	// PostgreSQL is the default dialect.
+	PostgreSQL Dialect = PostgreSQLDialect{}
+	// MySQL uses "?" placeholders and backtick quoted identifiers.
+	MySQL Dialect = MySQLDialect{Quoting: QuoteAlways}
+	// SQLite uses "?NNN" placeholders and supports every feature of the latest SQLite release.
+	SQLite Dialect = SQLiteDialect{}
+)
+
+// PostgreSQLDialect is the dialect used for PostgreSQL.
+type PostgreSQLDialect struct {
+	// Quoting defines when identifiers are quoted.
+	Quoting Quoting
+	// Strict rejects identifiers that don't match a safe identifier pattern.
+	Strict bool
+}
+
+// Name returns the dialect name.
+func (PostgreSQLDialect) Name() string {
+	return "postgresql"
+}
+
+// Placeholder returns the positional placeholder of the argument at given index (starting at 1).
+func (dialect PostgreSQLDialect) Placeholder(index int) string {
+	return string(dialect.AppendPlaceholder(nil, index))
+}
+
+// AppendPlaceholder appends the positional placeholder of the argument at given index to dst.
+func (PostgreSQLDialect) AppendPlaceholder(dst []byte, index int) []byte {
+	return strconv.AppendInt(append(dst, '$'), int64(index), 10)
+}
+
+// QuoteIdentifier returns given identifier, such as "schema.table.column", quoted for this dialect.
+func (dialect PostgreSQLDialect) QuoteIdentifier(identifier string) (string, error) {
+	return dialect.quoter().identifier(identifier)
+}
+
+// QuoteAlias returns given alias quoted for this dialect.
+func (dialect PostgreSQLDialect) QuoteAlias(alias string) (string, error) {
+	return dialect.quoter().alias(alias)
+}
+
+// Supports returns true if dialect supports given feature.
+func (PostgreSQLDialect) Supports(feature Feature) bool {
+	return feature != FeatureOnDuplicateKey
+}
+
+// PostgreSQL folds unquoted identifiers to lower case.
+func (dialect PostgreSQLDialect) quoter() quoter {
+	return quoter{quote: '"', quoting: dialect.Quoting, strict: dialect.Strict, fold: true}
+}
+
+// MySQLDialect is the dialect used for MySQL and MariaDB.
+type MySQLDialect struct {
+	// Quoting defines when identifiers are quoted.
+	Quoting Quoting
+	// Strict rejects identifiers that don't match a safe identifier pattern.
+	Strict bool
+}
+
+// Name returns the dialect name.
+func (MySQLDialect) Name() string {
+	return "mysql"
+}
+
+// Placeholder returns the positional placeholder of the argument at given index (starting at 1).
+func (MySQLDialect) Placeholder(index int) string {
+	return "?"
+}
+
+// AppendPlaceholder appends the positional placeholder of the argument at given index to dst.
+func (MySQLDialect) AppendPlaceholder(dst []byte, index int) []byte {
+	return append(dst, '?')
+}
+
+// QuoteIdentifier returns given identifier, such as "schema.table.column", quoted for this dialect.
+func (dialect MySQLDialect) QuoteIdentifier(identifier string) (string, error) {
+	return dialect.quoter().identifier(identifier)
+}
+
+// QuoteAlias returns given alias quoted for this dialect.
+func (dialect MySQLDialect) QuoteAlias(alias string) (string, error) {
+	return dialect.quoter().alias(alias)
+}
+
+// Supports returns true if dialect supports given feature.
+func (MySQLDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureOnDuplicateKey:
+		return true
+	case FeatureReturning, FeatureOnly, FeatureILike, FeatureUsing, FeatureUpdateFrom,
+		FeatureSetColumnList, FeatureOnConflict, FeatureConcatOperator, FeatureFrameGroups, FeatureKeyLocking,
+		FeatureDistinctOn, FeatureMaterialized, FeatureDataModifyingWith, FeatureInsertWith,
+		FeatureNumberedPlaceholders:
+		return false
+	default:
+		return true
+	}
+}
+
+func (dialect MySQLDialect) quoter() quoter {
+	return quoter{quote: '`', quoting: dialect.Quoting, strict: dialect.Strict}
+}
+
+// SQLiteDialect is the dialect used for SQLite.
+type SQLiteDialect struct {
+	// Anonymous uses "?" placeholders instead of "?NNN".
+	Anonymous bool
+	// Version is the SQLite version number, as returned by sqlite3_libversion_number() (3035000 for 3.35.0).
+	// Features that are not available on this version are rejected.
+	// If undefined, the latest version is assumed.
+	Version int
+	// Quoting defines when identifiers are quoted.
+	Quoting Quoting
+	// Strict rejects identifiers that don't match a safe identifier pattern.
+	Strict bool
+}
+
+// Name returns the dialect name.
+func (SQLiteDialect) Name() string {
+	return "sqlite"
+}
+
+// Placeholder returns the positional placeholder of the argument at given index (starting at 1).
+func (dialect SQLiteDialect) Placeholder(index int) string {
+	return string(dialect.AppendPlaceholder(nil, index))
+}
+
+// AppendPlaceholder appends the positional placeholder of the argument at given index to dst.
+func (dialect SQLiteDialect) AppendPlaceholder(dst []byte, index int) []byte {
+	dst = append(dst, '?')
+	if dialect.Anonymous {
+		return dst
+	}
+	return strconv.AppendInt(dst, int64(index), 10)
+}
+
+// QuoteIdentifier returns given identifier, such as "schema.table.column", quoted for this dialect.
+func (dialect SQLiteDialect) QuoteIdentifier(identifier string) (string, error) {
+	return dialect.quoter().identifier(identifier)
+}
+
+// QuoteAlias returns given alias quoted for this dialect.
+func (dialect SQLiteDialect) QuoteAlias(alias string) (string, error) {
+	return dialect.quoter().alias(alias)
+}
+
+// Supports returns true if dialect supports given feature.
+func (dialect SQLiteDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureReturning, FeatureMaterialized:
+		return dialect.since(3035000)
+	case FeatureUpdateFrom:
+		return dialect.since(3033000)
+	case FeatureOnConflict:
+		return dialect.since(3024000)
+	case FeatureSetColumnList:
+		return dialect.since(3015000)
+	case FeatureFrameGroups:
+		return dialect.since(3028000)
+	case FeatureWindow:
+		return dialect.since(3025000)
+	case FeatureNumberedPlaceholders:
+		return !dialect.Anonymous
+	case FeatureOnly, FeatureILike, FeatureUsing, FeatureOnDuplicateKey, FeatureCompoundParenthesis,
+		FeatureLocking, FeatureKeyLocking, FeatureDistinctOn, FeatureDataModifyingWith:
+		return false
+	default:
+		return true
+	}
+}
+
+func (dialect SQLiteDialect) since(version int) bool {
+	return dialect.Version == 0 || dialect.Version >= version
+}
+
+func (dialect SQLiteDialect) quoter() quoter {
+	return quoter{quote: '"', quoting: dialect.Quoting, strict: dialect.Strict}
+}
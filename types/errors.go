@@ -0,0 +1,101 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors kinds that can be matched with errors.Is().
+var (
+	// ErrDuplicateClause is returned when a clause is defined twice.
+	ErrDuplicateClause = errors.New("duplicate clause")
+	// ErrInvalidLimit is returned when a limit isn't a positive integer.
+	ErrInvalidLimit = errors.New("invalid limit")
+	// ErrInvalidOffset is returned when an offset isn't a non-negative integer.
+	ErrInvalidOffset = errors.New("invalid offset")
+	// ErrInvalidColumn is returned when a column is undefined or has an unsupported type.
+	ErrInvalidColumn = errors.New("invalid column")
+	// ErrInvalidTable is returned when a table is undefined or has an unsupported type.
+	ErrInvalidTable = errors.New("invalid table")
+	// ErrInvalidClause is returned when a clause is undefined or has an unsupported type.
+	ErrInvalidClause = errors.New("invalid clause")
+	// ErrInvalidExpression is returned when an expression is undefined or has an unsupported type.
+	ErrInvalidExpression = errors.New("invalid expression")
+	// ErrInvalidCondition is returned when a condition is missing.
+	ErrInvalidCondition = errors.New("invalid condition")
+	// ErrInvalidPairs is returned when pairs of a SET clause are invalid.
+	ErrInvalidPairs = errors.New("invalid pairs")
+	// ErrInvalidIdentifier is returned when an identifier is rejected by a strict dialect.
+	ErrInvalidIdentifier = errors.New("invalid identifier")
+	// ErrEmptyStatement is returned when a statement lacks a required clause.
+	ErrEmptyStatement = errors.New("empty statement")
+	// ErrUnsupportedFeature is returned when a feature isn't supported by a dialect.
+	ErrUnsupportedFeature = errors.New("unsupported feature")
+	// ErrTooManyParameters is returned when a statement cannot be split under a parameters limit.
+	ErrTooManyParameters = errors.New("too many parameters")
+	// ErrInvalidParameter is returned when a named parameter is invalid, missing or unknown.
+	ErrInvalidParameter = errors.New("invalid parameter")
+)
+
+// Error is an error found while building or writing a statement.
+type Error struct {
+	Kind    error
+	Message string
+}
+
+// NewError returns a new Error of given kind.
+func NewError(kind error, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Message: message,
+	}
+}
+
+// NewErrorf returns a new Error of given kind, using a format specifier for its message.
+func NewErrorf(kind error, format string, args ...interface{}) *Error {
+	return NewError(kind, fmt.Sprintf(format, args...))
+}
+
+func (e *Error) Error() string {
+	return "loukoum: " + e.Message
+}
+
+// Unwrap returns the error kind.
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// Errors is a list of errors found while building or writing a statement.
+type Errors []error
+
+// AppendError appends given error to the list, without sharing its backing array.
+func AppendError(errs Errors, err error) Errors {
+	list := make(Errors, 0, len(errs)+1)
+	list = append(list, errs...)
+	return append(list, err)
+}
+
+// Err returns the list as an error, or nil if it's empty.
+func (errs Errors) Err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (errs Errors) Error() string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+	messages := make([]string, 0, len(errs))
+	for i := range errs {
+		messages = append(messages, errs[i].Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the list of errors.
+func (errs Errors) Unwrap() []error {
+	return errs
+}
@@ -0,0 +1,269 @@
+package types
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Quoting defines when identifiers are quoted by a dialect.
+type Quoting uint8
+
+const (
+	// QuoteNever writes identifiers as is.
+	QuoteNever = Quoting(iota)
+	// QuoteWhenNeeded quotes identifiers parts that are reserved words, use mixed case or special characters.
+	QuoteWhenNeeded
+	// QuoteAlways quotes every identifiers parts.
+	QuoteAlways
+)
+
+// IdentifierPart is a part of a dotted identifier, such as "schema.table.column".
+type IdentifierPart struct {
+	Name   string
+	Quoted bool
+}
+
+// ParseIdentifier splits given identifier, such as "schema.table.column", in parts.
+// Each part is either a word, a quoted name (using double quotes or backticks), or a trailing wildcard.
+// It returns false if value isn't an identifier (an expression, a function call, etc...).
+func ParseIdentifier(identifier string) ([]IdentifierPart, bool) {
+	parts := []IdentifierPart{}
+	value := identifier
+
+	for {
+		part, rest, ok := parseIdentifierPart(value)
+		if !ok {
+			return nil, false
+		}
+		parts = append(parts, part)
+
+		if rest == "" {
+			return parts, true
+		}
+		if rest[0] != '.' || (part.Name == "*" && !part.Quoted) {
+			return nil, false
+		}
+		value = rest[1:]
+	}
+}
+
+// parseIdentifierPart reads an identifier part at the beginning of given value.
+func parseIdentifierPart(value string) (IdentifierPart, string, bool) {
+	if value == "" {
+		return IdentifierPart{}, "", false
+	}
+
+	if value[0] == '"' || value[0] == '`' {
+		quote := value[0]
+		name := strings.Builder{}
+		for i := 1; i < len(value); i++ {
+			if value[i] != quote {
+				name.WriteByte(value[i])
+				continue
+			}
+			// A doubled quote is an escaped quote.
+			if i+1 < len(value) && value[i+1] == quote {
+				name.WriteByte(quote)
+				i++
+				continue
+			}
+			if name.Len() == 0 {
+				return IdentifierPart{}, "", false
+			}
+			return IdentifierPart{Name: name.String(), Quoted: true}, value[i+1:], true
+		}
+		return IdentifierPart{}, "", false
+	}
+
+	if value[0] == '*' {
+		return IdentifierPart{Name: "*"}, value[1:], true
+	}
+
+	end := len(value)
+	for i, char := range value {
+		if char == '.' {
+			end = i
+			break
+		}
+		if !isWordRune(char, i == 0) {
+			return IdentifierPart{}, "", false
+		}
+	}
+
+	return IdentifierPart{Name: value[:end]}, value[end:], true
+}
+
+func isWordRune(char rune, first bool) bool {
+	if char == '_' || unicode.IsLetter(char) {
+		return true
+	}
+	return !first && (char == '$' || unicode.IsDigit(char))
+}
+
+// isSafeIdentifier returns true if given name matches [A-Za-z_][A-Za-z0-9_]*.
+func isSafeIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, char := range name {
+		switch {
+		case char == '_', 'a' <= char && char <= 'z', 'A' <= char && char <= 'Z':
+		case i > 0 && '0' <= char && char <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isDottedName returns true if given name is made of safe identifiers separated by dots, such as "author.id".
+func isDottedName(name string) bool {
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	for i := range parts {
+		if !isSafeIdentifier(parts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isMixedCase returns true if given name has both lower case and upper case letters.
+func isMixedCase(name string) bool {
+	return strings.ToLower(name) != name && strings.ToUpper(name) != name
+}
+
+// quoter quotes identifiers for a dialect.
+type quoter struct {
+	quote   byte
+	quoting Quoting
+	strict  bool
+	// fold indicates that unquoted identifiers are folded to lower case.
+	fold bool
+}
+
+// identifier returns given identifier, such as "schema.table.column", quoted.
+func (q quoter) identifier(identifier string) (string, error) {
+	if q.quoting == QuoteNever && !q.strict {
+		return identifier, nil
+	}
+
+	parts, ok := ParseIdentifier(identifier)
+	if !ok {
+		return q.invalid(identifier)
+	}
+
+	buffer := strings.Builder{}
+	for i := range parts {
+		if i > 0 {
+			buffer.WriteByte('.')
+		}
+		part, err := q.part(identifier, parts[i])
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(part)
+	}
+
+	return buffer.String(), nil
+}
+
+// alias returns given alias quoted.
+// A dotted alias, such as "author.id", is a single name: it's always quoted, since it would be read as a
+// qualified identifier otherwise.
+func (q quoter) alias(alias string) (string, error) {
+	if isDottedName(alias) {
+		return q.wrap(alias), nil
+	}
+	if q.quoting == QuoteNever && !q.strict {
+		return alias, nil
+	}
+
+	part, rest, ok := parseIdentifierPart(alias)
+	if !ok || rest != "" || (part.Name == "*" && !part.Quoted) {
+		return q.invalid(alias)
+	}
+
+	return q.part(alias, part)
+}
+
+// invalid handles a value that isn't an identifier: it's rejected in strict mode, and kept as is otherwise.
+func (q quoter) invalid(value string) (string, error) {
+	if q.strict {
+		return "", NewErrorf(ErrInvalidIdentifier, "%q is not a valid identifier", value)
+	}
+	return value, nil
+}
+
+func (q quoter) part(identifier string, part IdentifierPart) (string, error) {
+	if part.Name == "*" && !part.Quoted {
+		return part.Name, nil
+	}
+	if q.strict && (part.Quoted || !isSafeIdentifier(part.Name)) {
+		return "", NewErrorf(ErrInvalidIdentifier, "%q is not a valid identifier", identifier)
+	}
+	if part.Quoted {
+		return q.wrap(part.Name), nil
+	}
+
+	switch q.quoting {
+	case QuoteAlways:
+		return q.wrap(q.normalize(part.Name)), nil
+	case QuoteWhenNeeded:
+		if isReservedWord(part.Name) || isMixedCase(part.Name) || !isSafeIdentifier(part.Name) {
+			return q.wrap(q.normalize(part.Name)), nil
+		}
+	}
+
+	return part.Name, nil
+}
+
+// normalize applies case folding on given unquoted name, so that quoting it doesn't change its meaning.
+// Mixed case names are kept as is since they are assumed to be case sensitive.
+func (q quoter) normalize(name string) string {
+	if q.fold && !isMixedCase(name) {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+func (q quoter) wrap(name string) string {
+	quote := string(q.quote)
+	return quote + strings.Replace(name, quote, quote+quote, -1) + quote
+}
+
+func isReservedWord(name string) bool {
+	_, ok := reservedWords[strings.ToUpper(name)]
+	return ok
+}
+
+// reservedWords contains keywords that are reserved by at least one supported dialect.
+// Quoting an identifier that isn't reserved by current dialect is harmless.
+var reservedWords = map[string]struct{}{
+	"ADD": {}, "ALL": {}, "ALTER": {}, "ANALYSE": {}, "ANALYZE": {}, "AND": {}, "ANY": {}, "ARRAY": {},
+	"AS": {}, "ASC": {}, "ASYMMETRIC": {}, "AUTHORIZATION": {}, "AUTOINCREMENT": {}, "BETWEEN": {},
+	"BINARY": {}, "BOTH": {}, "BY": {}, "CALL": {}, "CASCADE": {}, "CASE": {}, "CAST": {}, "CHANGE": {},
+	"CHECK": {}, "COLLATE": {}, "COLLATION": {}, "COLUMN": {}, "COMMIT": {}, "CONCURRENTLY": {},
+	"CONDITION": {}, "CONFLICT": {}, "CONSTRAINT": {}, "CONVERT": {}, "CREATE": {}, "CROSS": {},
+	"CURRENT_CATALOG": {}, "CURRENT_DATE": {}, "CURRENT_ROLE": {}, "CURRENT_SCHEMA": {},
+	"CURRENT_TIME": {}, "CURRENT_TIMESTAMP": {}, "CURRENT_USER": {}, "DATABASE": {}, "DATABASES": {},
+	"DEFAULT": {}, "DEFERRABLE": {}, "DELETE": {}, "DESC": {}, "DESCRIBE": {}, "DISTINCT": {}, "DIV": {},
+	"DO": {}, "DROP": {}, "DUAL": {}, "ELSE": {}, "END": {}, "ESCAPE": {}, "EXCEPT": {}, "EXISTS": {},
+	"EXPLAIN": {}, "FALSE": {}, "FETCH": {}, "FOR": {}, "FOREIGN": {}, "FREEZE": {}, "FROM": {}, "FULL": {},
+	"FULLTEXT": {}, "GLOB": {}, "GRANT": {}, "GROUP": {}, "GROUPS": {}, "HAVING": {}, "IF": {}, "IGNORE": {},
+	"ILIKE": {}, "IN": {}, "INDEX": {}, "INITIALLY": {}, "INNER": {}, "INSERT": {}, "INTERSECT": {},
+	"INTERVAL": {}, "INTO": {}, "IS": {}, "ISNULL": {}, "JOIN": {}, "KEY": {}, "KEYS": {}, "KILL": {},
+	"LATERAL": {}, "LEADING": {}, "LEFT": {}, "LIKE": {}, "LIMIT": {}, "LINES": {}, "LOAD": {},
+	"LOCALTIME": {}, "LOCALTIMESTAMP": {}, "LOCK": {}, "MATCH": {}, "MOD": {}, "NATURAL": {}, "NOT": {},
+	"NOTNULL": {}, "NULL": {}, "OFFSET": {}, "ON": {}, "ONLY": {}, "OPTION": {}, "OR": {}, "ORDER": {},
+	"OUTER": {}, "OVER": {}, "OVERLAPS": {}, "PARTITION": {}, "PLACING": {}, "PRIMARY": {},
+	"PROCEDURE": {}, "RANGE": {}, "RANK": {}, "READ": {}, "RECURSIVE": {}, "REFERENCES": {}, "REGEXP": {},
+	"RENAME": {}, "REPLACE": {}, "RETURNING": {}, "REVOKE": {}, "RIGHT": {}, "RLIKE": {}, "ROW": {},
+	"ROWS": {}, "SCHEMA": {}, "SELECT": {}, "SESSION_USER": {}, "SET": {}, "SHOW": {}, "SIMILAR": {},
+	"SOME": {}, "SYMMETRIC": {}, "TABLE": {}, "TABLESAMPLE": {}, "THEN": {}, "TO": {}, "TRAILING": {},
+	"TRIGGER": {}, "TRUE": {}, "UNION": {}, "UNIQUE": {}, "UNLOCK": {}, "UPDATE": {}, "USAGE": {},
+	"USE": {}, "USER": {}, "USING": {}, "VALUES": {}, "VARIADIC": {}, "VERBOSE": {}, "WHEN": {},
+	"WHERE": {}, "WINDOW": {}, "WITH": {}, "WRITE": {}, "XOR": {},
+}
@@ -8,3 +8,27 @@ type Pair struct {
 	Key   interface{}
 	Value interface{}
 }
+
+// Only returns a copy of the map, restricted to given keys.
+func (m Map) Only(keys ...string) Map {
+	values := make(Map, len(keys))
+	for i := range keys {
+		value, ok := m[keys[i]]
+		if ok {
+			values[keys[i]] = value
+		}
+	}
+	return values
+}
+
+// Without returns a copy of the map, without given keys.
+func (m Map) Without(keys ...string) Map {
+	values := make(Map, len(m))
+	for key, value := range m {
+		values[key] = value
+	}
+	for i := range keys {
+		delete(values, keys[i])
+	}
+	return values
+}
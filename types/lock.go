@@ -0,0 +1,35 @@
+package types
+
+// LockStrength represents the strength of a row-level locking clause.
+type LockStrength string
+
+func (e LockStrength) String() string {
+	return string(e)
+}
+
+// Lock strengths.
+const (
+	// ForUpdate locks selected rows as though for update.
+	ForUpdate = LockStrength("FOR UPDATE")
+	// ForNoKeyUpdate is a weaker FOR UPDATE lock, that doesn't block FOR KEY SHARE locks.
+	ForNoKeyUpdate = LockStrength("FOR NO KEY UPDATE")
+	// ForShare acquires a shared lock on selected rows.
+	ForShare = LockStrength("FOR SHARE")
+	// ForKeyShare is a weaker FOR SHARE lock, that only blocks FOR UPDATE locks.
+	ForKeyShare = LockStrength("FOR KEY SHARE")
+)
+
+// LockWait represents the behavior of a row-level locking clause when rows are already locked.
+type LockWait string
+
+func (e LockWait) String() string {
+	return string(e)
+}
+
+// Lock wait policies.
+const (
+	// NoWait reports an error if a selected row cannot be locked immediately.
+	NoWait = LockWait("NOWAIT")
+	// SkipLocked skips selected rows that cannot be locked immediately.
+	SkipLocked = LockWait("SKIP LOCKED")
+)
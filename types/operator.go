@@ -40,3 +40,52 @@ const (
 	Between            = ComparisonOperator("BETWEEN")
 	NotBetween         = ComparisonOperator("NOT BETWEEN")
 )
+
+// ArithmeticOperator represents an arithmetic, concatenation or bitwise operator.
+type ArithmeticOperator string
+
+func (e ArithmeticOperator) String() string {
+	return string(e)
+}
+
+// Arithmetic operators.
+const (
+	Add               = ArithmeticOperator("+")
+	Subtract          = ArithmeticOperator("-")
+	Multiply          = ArithmeticOperator("*")
+	Divide            = ArithmeticOperator("/")
+	Modulo            = ArithmeticOperator("%")
+	Concat            = ArithmeticOperator("||")
+	BitwiseAnd        = ArithmeticOperator("&")
+	BitwiseOr         = ArithmeticOperator("|")
+	BitwiseShiftLeft  = ArithmeticOperator("<<")
+	BitwiseShiftRight = ArithmeticOperator(">>")
+)
+
+// UnaryOperator represents an unary operator.
+type UnaryOperator string
+
+func (e UnaryOperator) String() string {
+	return string(e)
+}
+
+// Unary operators.
+const (
+	Negate     = UnaryOperator("-")
+	BitwiseNot = UnaryOperator("~")
+)
+
+// SetOperator represents an operator combining the results of two queries.
+type SetOperator string
+
+func (e SetOperator) String() string {
+	return string(e)
+}
+
+// Set operators.
+const (
+	Union     = SetOperator("UNION")
+	UnionAll  = SetOperator("UNION ALL")
+	Intersect = SetOperator("INTERSECT")
+	Except    = SetOperator("EXCEPT")
+)
@@ -0,0 +1,12 @@
+package types
+
+// Param is a named parameter bound by a context in place of a value.
+// Its value is given when the query is executed, so that a query can be generated once and executed many times.
+type Param struct {
+	Name string
+}
+
+// IsValid returns true if parameter name can be used as a named placeholder.
+func (param Param) IsValid() bool {
+	return isSafeIdentifier(param.Name)
+}
@@ -0,0 +1,39 @@
+package types
+
+// FrameMode represents the mode of a window frame.
+type FrameMode string
+
+func (e FrameMode) String() string {
+	return string(e)
+}
+
+// Frame modes.
+const (
+	// Rows defines a frame using a number of rows.
+	Rows = FrameMode("ROWS")
+	// Range defines a frame using a range of values of the ordering column.
+	Range = FrameMode("RANGE")
+	// Groups defines a frame using a number of peer groups.
+	Groups = FrameMode("GROUPS")
+)
+
+// FrameBoundType represents the type of a window frame bound.
+type FrameBoundType string
+
+func (e FrameBoundType) String() string {
+	return string(e)
+}
+
+// Frame bound types.
+const (
+	// UnboundedPreceding indicates that the frame starts with the first row of the partition.
+	UnboundedPreceding = FrameBoundType("UNBOUNDED PRECEDING")
+	// Preceding indicates that the frame bound is an offset before the current row.
+	Preceding = FrameBoundType("PRECEDING")
+	// CurrentRow indicates that the frame bound is the current row.
+	CurrentRow = FrameBoundType("CURRENT ROW")
+	// Following indicates that the frame bound is an offset after the current row.
+	Following = FrameBoundType("FOLLOWING")
+	// UnboundedFollowing indicates that the frame ends with the last row of the partition.
+	UnboundedFollowing = FrameBoundType("UNBOUNDED FOLLOWING")
+)
@@ -0,0 +1,16 @@
+package types
+
+// Materialization represents a materialization hint of a common table expression.
+type Materialization string
+
+func (e Materialization) String() string {
+	return string(e)
+}
+
+// Materialization hints.
+const (
+	// Materialized forces the common table expression to be computed once.
+	Materialized = Materialization("MATERIALIZED")
+	// NotMaterialized allows the common table expression to be folded into the parent query.
+	NotMaterialized = Materialization("NOT MATERIALIZED")
+)